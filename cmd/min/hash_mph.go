@@ -0,0 +1,140 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/focela/min/encoding/minhash"
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/internal/command"
+)
+
+// runHashMPH runs command "hash mph", which builds the minimal perfect hash of the keys of a file,
+// and writes it in binary format or as Go source.
+//
+// Options:
+//
+//	-in       File of keys, one key per line, empty lines are ignored. The standard input in default.
+//	-algo     Name of the hash algorithm, xxh64 in default.
+//	-out      File to write the binary format of the perfect hash.
+//	-go       File to write the Go source of the perfect hash and its keys.
+//	-package  Package name of the Go source, main in default.
+//	-name     Prefix of the identifiers of the Go source, table in default.
+func runHashMPH(_ []string) error {
+	var (
+		in     = command.GetOption("in")
+		out    = command.GetOption("out")
+		goFile = command.GetOption("go")
+		reader io.Reader
+	)
+	if out == "" && goFile == "" {
+		return minerror.NewCode(mincode.CodeMissingParameter, `option "out" or "go" is required`)
+	}
+	hasher, err := minhash.Lookup(command.GetOption("algo", "xxh64"))
+	if err != nil {
+		return err
+	}
+	if in == "" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(in)
+		if err != nil {
+			return minerror.Wrapf(err, `open keys file "%s" failed`, in)
+		}
+		defer file.Close()
+		reader = file
+	}
+	keys, err := readKeys(reader)
+	if err != nil {
+		return err
+	}
+	table, err := minhash.NewPerfectHash(keys, hasher)
+	if err != nil {
+		return err
+	}
+	data, err := table.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if out != "" {
+		if err = os.WriteFile(out, data, 0o644); err != nil {
+			return minerror.Wrapf(err, `write perfect hash file "%s" failed`, out)
+		}
+	}
+	if goFile != "" {
+		src, err := perfectHashSource(
+			command.GetOption("package", "main"), command.GetOption("name", "table"), table, keys, data,
+		)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(goFile, src, 0o644); err != nil {
+			return minerror.Wrapf(err, `write Go source file "%s" failed`, goFile)
+		}
+	}
+	fmt.Printf("%d keys, %d bytes\n", len(keys), len(data))
+	return nil
+}
+
+// readKeys reads the non-empty lines of `reader` as keys.
+func readKeys(reader io.Reader) ([][]byte, error) {
+	var (
+		keys    [][]byte
+		scanner = bufio.NewScanner(reader)
+	)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if line := bytes.TrimSuffix(scanner.Bytes(), []byte("\r")); len(line) > 0 {
+			keys = append(keys, bytes.Clone(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, minerror.Wrap(err, "read keys failed")
+	}
+	return keys, nil
+}
+
+// perfectHashSource returns the formatted Go source of package `pkg`, which declares the keys ordered by
+// their indexes, the perfect hash decoded from `data`, and a lookup function, named with prefix `name`.
+func perfectHashSource(pkg, name string, table *minhash.PerfectHash, keys [][]byte, data []byte) ([]byte, error) {
+	ordered := make([][]byte, len(keys))
+	for _, key := range keys {
+		ordered[table.Index(key)] = key
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by \"min hash mph\"; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import \"github.com/focela/min/encoding/minhash\"\n\n")
+	fmt.Fprintf(&b, "// %sKeys are the keys ordered by their perfect hash indexes.\n", name)
+	fmt.Fprintf(&b, "var %sKeys = [...]string{\n", name)
+	for _, key := range ordered {
+		fmt.Fprintf(&b, "\t%q,\n", key)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "// %sHash is the minimal perfect hash of %sKeys.\n", name, name)
+	fmt.Fprintf(&b, "var %sHash = func() *minhash.PerfectHash {\n", name)
+	fmt.Fprintf(&b, "\th := new(minhash.PerfectHash)\n")
+	fmt.Fprintf(&b, "\tif err := h.UnmarshalBinary([]byte(%q)); err != nil {\n\t\tpanic(err)\n\t}\n", data)
+	fmt.Fprintf(&b, "\treturn h\n}()\n\n")
+	fmt.Fprintf(&b, "// %sIndex returns the index of `key` in %sKeys, or -1 if `key` is not found.\n", name, name)
+	fmt.Fprintf(&b, "func %sIndex(key string) int {\n", name)
+	fmt.Fprintf(&b, "\ti := %sHash.IndexString(key)\n", name)
+	fmt.Fprintf(&b, "\tif %sKeys[i] != key {\n\t\treturn -1\n\t}\n", name)
+	fmt.Fprintf(&b, "\treturn int(i)\n}\n")
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, minerror.Wrap(err, "format Go source failed")
+	}
+	return src, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+
+	"github.com/focela/min/encoding/minhash"
+	"github.com/focela/min/encoding/minhash/quality"
+)
+
+// runHashQuality runs command "hash quality", which prints the comparative quality report of hash algorithms.
+//
+// Options:
+//
+//	-algo     Comma-separated names of algorithms, all registered algorithms in default.
+//	-corpus   Comma-separated names of corpora among ints, uuids and paths, all corpora in default.
+//	-keys     Number of keys for distribution and collision tests, 100000 in default.
+//	-buckets  Number of buckets for the chi-square test, 1024 in default.
+//	-samples  Number of keys for avalanche tests, 1000 in default.
+func runHashQuality(_ []string) error {
+	var (
+		hashers []minhash.Hasher
+		corpora []quality.Corpus
+		option  quality.Option
+		err     error
+	)
+	for _, name := range listOption("algo", minhash.List()) {
+		hasher, err := minhash.Lookup(name)
+		if err != nil {
+			return err
+		}
+		hashers = append(hashers, hasher)
+	}
+	for _, name := range listOption("corpus", nil) {
+		corpus, err := quality.LookupCorpus(name)
+		if err != nil {
+			return err
+		}
+		corpora = append(corpora, corpus)
+	}
+	if len(corpora) == 0 {
+		corpora = quality.Corpora()
+	}
+	if option.Keys, err = intOption("keys", 0); err != nil {
+		return err
+	}
+	if option.Buckets, err = intOption("buckets", 0); err != nil {
+		return err
+	}
+	if option.Samples, err = intOption("samples", 0); err != nil {
+		return err
+	}
+	return quality.Report(os.Stdout, quality.Run(hashers, corpora, option))
+}
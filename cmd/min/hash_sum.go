@@ -0,0 +1,259 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/focela/min/encoding/minhash"
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/internal/command"
+)
+
+// fileChecksum is the checksum of a file.
+type fileChecksum struct {
+	path string
+	sum  string // Hex digest of the file, empty if err is not nil.
+	err  error
+}
+
+// runHashSum runs command "hash sum", which prints the checksums of files in sha256sum-compatible format.
+// The arguments are files or directories, and the regular files of directories are walked recursively.
+//
+// Options:
+//
+//	-algo      Name of the hash algorithm, xxh64 in default.
+//	-parallel  Number of files hashed in parallel, the number of CPUs in default.
+func runHashSum(args []string) error {
+	if len(args) == 0 {
+		return minerror.NewCode(mincode.CodeMissingParameter, "files or directories are required")
+	}
+	hasher, parallel, err := checksumOptions()
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, arg := range args {
+		// The arguments are followed if they are symbolic links, while symbolic links met in walking are skipped.
+		info, err := os.Stat(arg)
+		if err != nil {
+			return minerror.Wrapf(err, `stat "%s" failed`, arg)
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		// The root with a trailing separator is walked as the directory it links to if it is a symbolic link.
+		root := arg
+		if !os.IsPathSeparator(root[len(root)-1]) {
+			root += string(filepath.Separator)
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return minerror.Wrapf(err, `walk "%s" failed`, arg)
+		}
+	}
+	var failed int
+	for _, c := range fileChecksums(hasher, paths, parallel) {
+		if c.err != nil {
+			fmt.Fprintln(os.Stderr, c.err)
+			failed++
+			continue
+		}
+		fmt.Println(formatChecksumLine(c.sum, c.path))
+	}
+	if failed > 0 {
+		return minerror.NewCodef(mincode.CodeOperationFailed, "%d files could not be read", failed)
+	}
+	return nil
+}
+
+// runHashVerify runs command "hash verify", which verifies files against the checksums of manifest files
+// in sha256sum-compatible format, and prints the result of each file.
+//
+// Options:
+//
+//	-algo      Name of the hash algorithm of the manifest, xxh64 in default.
+//	-parallel  Number of files hashed in parallel, the number of CPUs in default.
+func runHashVerify(args []string) error {
+	if len(args) == 0 {
+		return minerror.NewCode(mincode.CodeMissingParameter, "manifest files are required")
+	}
+	hasher, parallel, err := checksumOptions()
+	if err != nil {
+		return err
+	}
+	var (
+		paths    []string
+		expected []string
+	)
+	for _, manifest := range args {
+		if err = readManifest(manifest, func(sum, path string) {
+			expected = append(expected, sum)
+			paths = append(paths, path)
+		}); err != nil {
+			return err
+		}
+	}
+	var unreadable, mismatched int
+	for i, c := range fileChecksums(hasher, paths, parallel) {
+		switch {
+		case c.err != nil:
+			fmt.Printf("%s: FAILED open or read\n", c.path)
+			unreadable++
+		case !strings.EqualFold(c.sum, expected[i]):
+			fmt.Printf("%s: FAILED\n", c.path)
+			mismatched++
+		default:
+			fmt.Printf("%s: OK\n", c.path)
+		}
+	}
+	if unreadable > 0 || mismatched > 0 {
+		return minerror.NewCodef(
+			mincode.CodeValidationFailed, "%d listed files could not be read, %d computed checksums did NOT match",
+			unreadable, mismatched,
+		)
+	}
+	return nil
+}
+
+// checksumOptions returns the hasher and parallelism of the checksum commands from the options.
+func checksumOptions() (minhash.Hasher, int, error) {
+	hasher, err := minhash.Lookup(command.GetOption("algo", "xxh64"))
+	if err != nil {
+		return nil, 0, err
+	}
+	parallel, err := intOption("parallel", runtime.NumCPU())
+	if err != nil {
+		return nil, 0, err
+	}
+	if parallel <= 0 {
+		return nil, 0, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid parallelism %d", parallel)
+	}
+	return hasher, parallel, nil
+}
+
+// fileChecksums computes the checksums of `paths` by `hasher` with `parallel` workers,
+// the results are in the same order as the paths.
+func fileChecksums(hasher minhash.Hasher, paths []string, parallel int) []fileChecksum {
+	var (
+		results = make([]fileChecksum, len(paths))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+	for w := 0; w < min(parallel, len(paths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				sum, err := fileChecksumOf(hasher, paths[i])
+				results[i] = fileChecksum{path: paths[i], sum: sum, err: err}
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// fileChecksumOf returns the hex digest of the file of `path` by `hasher`.
+func fileChecksumOf(hasher minhash.Hasher, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", minerror.Wrapf(err, `open file "%s" failed`, path)
+	}
+	defer file.Close()
+	h := hasher.New()
+	if _, err = io.Copy(h, file); err != nil {
+		return "", minerror.Wrapf(err, `read file "%s" failed`, path)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readManifest reads the checksum lines of the manifest file of `path`, and calls `f` for each line.
+// Empty lines and comment lines starting with "#" are ignored.
+func readManifest(path string, f func(sum, path string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return minerror.Wrapf(err, `open manifest file "%s" failed`, path)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sum, name, ok := parseChecksumLine(text)
+		if !ok {
+			return minerror.NewCodef(
+				mincode.CodeInvalidParameter, `invalid checksum line %d of manifest file "%s"`, line, path,
+			)
+		}
+		f(sum, name)
+	}
+	if err = scanner.Err(); err != nil {
+		return minerror.Wrapf(err, `read manifest file "%s" failed`, path)
+	}
+	return nil
+}
+
+// formatChecksumLine returns the checksum line of `sum` and `path` in sha256sum format.
+func formatChecksumLine(sum, path string) string {
+	prefix, path := escapeChecksumPath(path)
+	return prefix + sum + "  " + path
+}
+
+// escapeChecksumPath escapes the backslashes and newlines of `path` as sha256sum does,
+// the returned prefix is a backslash if `path` is escaped, which starts the output line.
+func escapeChecksumPath(path string) (prefix, escaped string) {
+	if !strings.ContainsAny(path, "\\\n") {
+		return "", path
+	}
+	return "\\", strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(path)
+}
+
+// parseChecksumLine parses a checksum line in sha256sum format, in which the separator of
+// the hex digest and the path is two spaces in text mode or a space and an asterisk in binary mode.
+func parseChecksumLine(line string) (sum, path string, ok bool) {
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	i := strings.IndexByte(line, ' ')
+	if i <= 0 || i+2 > len(line) || (line[i+1] != ' ' && line[i+1] != '*') {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(line[:i]); err != nil {
+		return "", "", false
+	}
+	sum, path = line[:i], line[i+2:]
+	if escaped {
+		path = strings.NewReplacer("\\\\", "\\", "\\n", "\n").Replace(path)
+	}
+	return sum, path, path != ""
+}
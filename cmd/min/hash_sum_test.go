@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_ChecksumLine(t *testing.T) {
+	for _, path := range []string{"file", "dir/a b.txt", "back\\slash", "new\nline", " leading", "*star"} {
+		line := formatChecksumLine("00ff", path)
+		if strings.Contains(line, "\n") {
+			t.Fatalf("line of %q contains a newline", path)
+		}
+		sum, parsed, ok := parseChecksumLine(line)
+		if !ok || sum != "00ff" || parsed != path {
+			t.Errorf("parseChecksumLine(%q) = %q, %q, %v", line, sum, parsed, ok)
+		}
+	}
+	// The binary mode of sha256sum is also accepted.
+	if sum, path, ok := parseChecksumLine("abcd *file"); !ok || sum != "abcd" || path != "file" {
+		t.Errorf("binary mode line = %q, %q, %v", sum, path, ok)
+	}
+	for _, line := range []string{"", "abcd", "abcd file", "abcd  ", "xyz  file", " abcd  file", "abcd -file"} {
+		if _, _, ok := parseChecksumLine(line); ok {
+			t.Errorf("parseChecksumLine(%q) succeeds", line)
+		}
+	}
+}
+
+func Test_FileChecksums(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		paths   []string
+		content = map[string]string{}
+	)
+	hasher, err := minhash.Lookup("xxh64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, data := range []string{"", "a", strings.Repeat("min", 100000)} {
+		path := filepath.Join(dir, string(rune('a'+i)))
+		if err = os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+		content[path] = data
+	}
+	paths = append(paths, filepath.Join(dir, "missing"))
+	for _, parallel := range []int{1, 2, 8} {
+		for i, c := range fileChecksums(hasher, paths, parallel) {
+			if c.path != paths[i] {
+				t.Fatalf("result %d is of %s, want %s", i, c.path, paths[i])
+			}
+			data, ok := content[c.path]
+			if !ok {
+				if c.err == nil {
+					t.Fatal("checksum of missing file succeeds")
+				}
+				continue
+			}
+			h := hasher.New()
+			h.Write([]byte(data))
+			if c.err != nil || c.sum != hex.EncodeToString(h.Sum(nil)) {
+				t.Fatalf("checksum of %s = %s, %v", c.path, c.sum, c.err)
+			}
+		}
+	}
+}
+
+func Test_ReadManifest(t *testing.T) {
+	var (
+		path     = filepath.Join(t.TempDir(), "manifest")
+		manifest = "# comment\r\n\n" + formatChecksumLine("0a0b", "a") + "\n" + formatChecksumLine("0c0d", "new\nline") + "\n"
+		lines    []string
+	)
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := readManifest(path, func(sum, path string) {
+		lines = append(lines, sum+"="+path)
+	})
+	if err != nil || len(lines) != 2 || lines[0] != "0a0b=a" || lines[1] != "0c0d=new\nline" {
+		t.Fatalf("readManifest = %q, %v", lines, err)
+	}
+	if err = os.WriteFile(path, []byte("0a0b  a\ninvalid\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = readManifest(path, func(string, string) {}); err == nil {
+		t.Fatal("readManifest of invalid line succeeds")
+	}
+}
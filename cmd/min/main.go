@@ -0,0 +1,117 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+// Command min provides command line tools for the packages of this module.
+//
+// Usage:
+//
+//	min <command> [options]
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/internal/command"
+)
+
+// subCommand is a command of the tool, which is selected by the leading words of the arguments.
+type subCommand struct {
+	name  string                    // Space-separated name of the command, eg: "hash quality".
+	brief string                    // Brief description for the usage.
+	run   func(args []string) error // Run runs the command with the arguments after its name.
+}
+
+// subCommands are all commands of the tool.
+var subCommands = []subCommand{
+	{
+		name:  "hash quality",
+		brief: "Analyze avalanche, bit independence, distribution and collisions of hash algorithms",
+		run:   runHashQuality,
+	},
+	{
+		name:  "hash mph",
+		brief: "Build the minimal perfect hash of static keys as binary or Go source",
+		run:   runHashMPH,
+	},
+	{
+		name:  "hash sum",
+		brief: "Print the checksums of files and directories in sha256sum format",
+		run:   runHashSum,
+	},
+	{
+		name:  "hash verify",
+		brief: "Verify files against the checksums of manifest files in sha256sum format",
+		run:   runHashVerify,
+	},
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command selected by the arguments.
+func run() error {
+	command.Init()
+	// The first argument is the program name.
+	args := command.GetAllArgs()[1:]
+	for _, c := range subCommands {
+		name := strings.Fields(c.name)
+		if len(args) >= len(name) && slices.Equal(args[:len(name)], name) {
+			return c.run(args[len(name):])
+		}
+	}
+	usage()
+	if len(args) == 0 || args[0] == "help" {
+		return nil
+	}
+	return minerror.NewCodef(mincode.CodeInvalidParameter, `unknown command "%s"`, strings.Join(args, " "))
+}
+
+// usage prints the usage of the tool.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: min <command> [options]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range subCommands {
+		fmt.Fprintf(os.Stderr, "  %-16s%s\n", c.name, c.brief)
+	}
+}
+
+// intOption returns the integer value of option `name`, or `def` if the option is absent.
+func intOption(name string, def int) (int, error) {
+	v := command.GetOption(name)
+	if v == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, minerror.NewCodef(mincode.CodeInvalidParameter, `invalid value "%s" of option "%s"`, v, name)
+	}
+	return i, nil
+}
+
+// listOption returns the comma-separated values of option `name`, or `def` if the option is absent.
+func listOption(name string, def []string) []string {
+	v := command.GetOption(name)
+	if v == "" {
+		return def
+	}
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -5,9 +5,26 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // AP implements the classic AP hash algorithm for 32 bits.
 func AP(str []byte) uint32 {
-	var hash uint32
+	return APSeed(0, str)
+}
+
+// APString is the same as AP for string `str`, which hashes `str` without copying it.
+func APString(str string) uint32 {
+	return AP(stringBytes(str))
+}
+
+// APSeed implements the classic AP hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as AP.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func APSeed(seed uint32, str []byte) uint32 {
+	var hash = seed
 	for i, b := range str {
 		if (i & 1) == 0 {
 			hash ^= (hash << 7) ^ uint32(b) ^ (hash >> 3)
@@ -18,9 +35,26 @@ func AP(str []byte) uint32 {
 	return hash
 }
 
+// APSeedString is the same as APSeed for string `str`, which hashes `str` without copying it.
+func APSeedString(seed uint32, str string) uint32 {
+	return APSeed(seed, stringBytes(str))
+}
+
 // AP64 implements the classic AP hash algorithm for 64 bits.
 func AP64(str []byte) uint64 {
-	var hash uint64
+	return AP64Seed(0, str)
+}
+
+// AP64String is the same as AP64 for string `str`, which hashes `str` without copying it.
+func AP64String(str string) uint64 {
+	return AP64(stringBytes(str))
+}
+
+// AP64Seed implements the classic AP hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as AP64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func AP64Seed(seed uint64, str []byte) uint64 {
+	var hash = seed
 	for i, b := range str {
 		if (i & 1) == 0 {
 			hash ^= (hash << 7) ^ uint64(b) ^ (hash >> 3)
@@ -30,3 +64,73 @@ func AP64(str []byte) uint64 {
 	}
 	return hash
 }
+
+// AP64SeedString is the same as AP64Seed for string `str`, which hashes `str` without copying it.
+func AP64SeedString(seed uint64, str string) uint64 {
+	return AP64Seed(seed, stringBytes(str))
+}
+
+type ap32 struct {
+	hash   uint32
+	offset uint64 // Number of bytes written, the algorithm depends on the parity of each byte index.
+}
+
+type ap64 struct {
+	hash   uint64
+	offset uint64 // Number of bytes written, the algorithm depends on the parity of each byte index.
+}
+
+// NewAP32 returns a new hash.Hash32 computing the AP hash algorithm for 32 bits.
+// Its Sum32 result is identical to AP for the same written data.
+func NewAP32() hash.Hash32 {
+	return &ap32{}
+}
+
+// NewAP64 returns a new hash.Hash64 computing the AP hash algorithm for 64 bits.
+// Its Sum64 result is identical to AP64 for the same written data.
+func NewAP64() hash.Hash64 {
+	return &ap64{}
+}
+
+func (s *ap32) Reset() { *s = ap32{} }
+func (s *ap64) Reset() { *s = ap64{} }
+
+func (s *ap32) Sum32() uint32 { return s.hash }
+func (s *ap64) Sum64() uint64 { return s.hash }
+
+func (s *ap32) Write(data []byte) (int, error) {
+	hash := s.hash
+	for i, b := range data {
+		if ((s.offset + uint64(i)) & 1) == 0 {
+			hash ^= (hash << 7) ^ uint32(b) ^ (hash >> 3)
+		} else {
+			hash ^= ^((hash << 11) ^ uint32(b) ^ (hash >> 5)) + 1
+		}
+	}
+	s.hash = hash
+	s.offset += uint64(len(data))
+	return len(data), nil
+}
+
+func (s *ap64) Write(data []byte) (int, error) {
+	hash := s.hash
+	for i, b := range data {
+		if ((s.offset + uint64(i)) & 1) == 0 {
+			hash ^= (hash << 7) ^ uint64(b) ^ (hash >> 3)
+		} else {
+			hash ^= ^((hash << 11) ^ uint64(b) ^ (hash >> 5)) + 1
+		}
+	}
+	s.hash = hash
+	s.offset += uint64(len(data))
+	return len(data), nil
+}
+
+func (s *ap32) Size() int { return 4 }
+func (s *ap64) Size() int { return 8 }
+
+func (s *ap32) BlockSize() int { return 1 }
+func (s *ap64) BlockSize() int { return 1 }
+
+func (s *ap32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, s.hash) }
+func (s *ap64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, s.hash) }
@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// benchmarkKeys returns `n` keys of the same form as the keys of sharded caches.
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "user:session:" + strconv.Itoa(i*7919)
+	}
+	return keys
+}
+
+// The conversion []byte(s) for a direct call of a hash function does not allocate, as the compiler
+// proves the bytes do not escape, so the String variants only save the copy of the key.
+// The conversion allocates for the calls of Hasher.Sum64, which SumString and SumStringBatch avoid.
+func Benchmark_BKDR64_Bytes(b *testing.B) {
+	keys := benchmarkKeys(1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.BKDR64([]byte(keys[i%len(keys)]))
+	}
+}
+
+func Benchmark_BKDR64_String(b *testing.B) {
+	keys := benchmarkKeys(1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.BKDR64String(keys[i%len(keys)])
+	}
+}
+
+func Benchmark_XXH64_Bytes(b *testing.B) {
+	keys := benchmarkKeys(1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.XXH64([]byte(keys[i%len(keys)]))
+	}
+}
+
+func Benchmark_XXH64_String(b *testing.B) {
+	keys := benchmarkKeys(1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.XXH64String(keys[i%len(keys)])
+	}
+}
+
+func Benchmark_Sum64_Bytes(b *testing.B) {
+	var (
+		hasher, _ = minhash.Lookup("xxh64")
+		keys      = benchmarkKeys(1024)
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = hasher.Sum64([]byte(keys[i%len(keys)]))
+	}
+}
+
+func Benchmark_SumString(b *testing.B) {
+	var (
+		hasher, _ = minhash.Lookup("xxh64")
+		keys      = benchmarkKeys(1024)
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.SumString(hasher, keys[i%len(keys)])
+	}
+}
+
+func Benchmark_Sum64_Loop(b *testing.B) {
+	var (
+		hasher, _ = minhash.Lookup("xxh64")
+		keys      = benchmarkKeys(1024)
+		dst       = make([]uint64, len(keys))
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(keys)))
+	for i := 0; i < b.N; i++ {
+		for j, key := range keys {
+			dst[j] = hasher.Sum64([]byte(key))
+		}
+	}
+}
+
+func Benchmark_SumStringBatch(b *testing.B) {
+	var (
+		hasher, _ = minhash.Lookup("xxh64")
+		keys      = benchmarkKeys(1024)
+		dst       = make([]uint64, len(keys))
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(keys)))
+	for i := 0; i < b.N; i++ {
+		minhash.SumStringBatch(hasher, dst, keys)
+	}
+}
+
+func Benchmark_SumString_Loop(b *testing.B) {
+	var (
+		hasher, _ = minhash.Lookup("xxh64")
+		keys      = benchmarkKeys(1024)
+		dst       = make([]uint64, len(keys))
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(keys)))
+	for i := 0; i < b.N; i++ {
+		for j, key := range keys {
+			dst[j] = minhash.SumString(hasher, key)
+		}
+	}
+}
+
+func Benchmark_XXH64Seed_Bytes(b *testing.B) {
+	var (
+		seed = minhash.ProcessSeed()
+		keys = benchmarkKeys(1024)
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.XXH64Seed(seed, []byte(keys[i%len(keys)]))
+	}
+}
+
+func Benchmark_XXH64Seed_String(b *testing.B) {
+	var (
+		seed = minhash.ProcessSeed()
+		keys = benchmarkKeys(1024)
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = minhash.XXH64SeedString(seed, keys[i%len(keys)])
+	}
+}
+
+func Test_SeedString(t *testing.T) {
+	var (
+		str  = "user:session:42"
+		data = []byte(str)
+	)
+	if minhash.DJBSeedString(7, str) != minhash.DJBSeed(7, data) ||
+		minhash.BKDR64SeedString(7, str) != minhash.BKDR64Seed(7, data) ||
+		minhash.Murmur128SeedString(7, str) != minhash.Murmur128Seed(7, data) ||
+		minhash.XXH3SeedString(7, str) != minhash.XXH3Seed(7, data) ||
+		minhash.XXH64SeedString(7, str) != minhash.XXH64Seed(7, data) {
+		t.Fatal("String variants of seeded hashes differ from the []byte variants")
+	}
+	if n := testing.AllocsPerRun(100, func() { minhash.XXH64SeedString(7, str) }); n != 0 {
+		t.Fatalf("XXH64SeedString allocates %v times", n)
+	}
+}
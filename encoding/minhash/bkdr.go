@@ -5,22 +5,115 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // BKDR implements the classic BKDR hash algorithm for 32 bits.
 func BKDR(str []byte) uint32 {
-	var seed uint32 = 131 // 31 131 1313 13131 131313 etc..
-	var hash uint32 = 0
+	return BKDRSeed(0, str)
+}
+
+// BKDRString is the same as BKDR for string `str`, which hashes `str` without copying it.
+func BKDRString(str string) uint32 {
+	return BKDR(stringBytes(str))
+}
+
+// BKDRSeed implements the classic BKDR hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as BKDR.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+// Note that the multiplier 131 of the algorithm is traditionally also named seed, which is unrelated to `seed`.
+func BKDRSeed(seed uint32, str []byte) uint32 {
+	var factor uint32 = 131 // 31 131 1313 13131 131313 etc..
+	var hash = seed
 	for _, b := range str {
-		hash = hash*seed + uint32(b)
+		hash = hash*factor + uint32(b)
 	}
 	return hash
 }
 
+// BKDRSeedString is the same as BKDRSeed for string `str`, which hashes `str` without copying it.
+func BKDRSeedString(seed uint32, str string) uint32 {
+	return BKDRSeed(seed, stringBytes(str))
+}
+
 // BKDR64 implements the classic BKDR hash algorithm for 64 bits.
 func BKDR64(str []byte) uint64 {
-	var seed uint64 = 131 // 31 131 1313 13131 131313 etc..
-	var hash uint64 = 0
+	return BKDR64Seed(0, str)
+}
+
+// BKDR64String is the same as BKDR64 for string `str`, which hashes `str` without copying it.
+func BKDR64String(str string) uint64 {
+	return BKDR64(stringBytes(str))
+}
+
+// BKDR64Seed implements the classic BKDR hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as BKDR64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+// Note that the multiplier 131 of the algorithm is traditionally also named seed, which is unrelated to `seed`.
+func BKDR64Seed(seed uint64, str []byte) uint64 {
+	var factor uint64 = 131 // 31 131 1313 13131 131313 etc..
+	var hash = seed
 	for _, b := range str {
-		hash = hash*seed + uint64(b)
+		hash = hash*factor + uint64(b)
 	}
 	return hash
 }
+
+// BKDR64SeedString is the same as BKDR64Seed for string `str`, which hashes `str` without copying it.
+func BKDR64SeedString(seed uint64, str string) uint64 {
+	return BKDR64Seed(seed, stringBytes(str))
+}
+
+type (
+	bkdr32 uint32
+	bkdr64 uint64
+)
+
+// NewBKDR32 returns a new hash.Hash32 computing the BKDR hash algorithm for 32 bits.
+// Its Sum32 result is identical to BKDR for the same written data.
+func NewBKDR32() hash.Hash32 {
+	var s bkdr32
+	return &s
+}
+
+// NewBKDR64 returns a new hash.Hash64 computing the BKDR hash algorithm for 64 bits.
+// Its Sum64 result is identical to BKDR64 for the same written data.
+func NewBKDR64() hash.Hash64 {
+	var s bkdr64
+	return &s
+}
+
+func (s *bkdr32) Reset() { *s = 0 }
+func (s *bkdr64) Reset() { *s = 0 }
+
+func (s *bkdr32) Sum32() uint32 { return uint32(*s) }
+func (s *bkdr64) Sum64() uint64 { return uint64(*s) }
+
+func (s *bkdr32) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash = hash*131 + bkdr32(b)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *bkdr64) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash = hash*131 + bkdr64(b)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *bkdr32) Size() int { return 4 }
+func (s *bkdr64) Size() int { return 8 }
+
+func (s *bkdr32) BlockSize() int { return 1 }
+func (s *bkdr64) BlockSize() int { return 1 }
+
+func (s *bkdr32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *bkdr64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
@@ -0,0 +1,198 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// bloomMagic is the magic of the binary format of Bloom.
+	bloomMagic = "MBLM"
+	// bloomMaxHashes is the maximum number of hash functions, which is optimal for false-positive rate 2^-64.
+	bloomMaxHashes = 64
+)
+
+// Bloom is a Bloom filter, which is a space-efficient probabilistic set.
+// It never reports false negatives, and reports false positives at a configured rate.
+// It is not safe for concurrent use.
+type Bloom struct {
+	hasher Hasher
+	m      uint64   // Number of bits.
+	k      uint64   // Number of hash functions.
+	bits   []uint64 // Bit array.
+}
+
+// NewBloom creates and returns a Bloom filter sized for `n` expected items with false-positive rate `p`.
+// The items are hashed by optional `hasher`, which is xxh64 in default.
+func NewBloom(n uint64, p float64, hasher ...Hasher) (*Bloom, error) {
+	m, k, err := bloomParams(n, p)
+	if err != nil {
+		return nil, err
+	}
+	b := &Bloom{
+		hasher: defaultHasher,
+		m:      m,
+		k:      k,
+		bits:   make([]uint64, (m+63)/64),
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		b.hasher = hasher[0]
+	}
+	return b, nil
+}
+
+// Add adds `data` to the filter.
+func (b *Bloom) Add(data []byte) {
+	h1, h2 := bloomHashes(b.hasher, data)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		b.bits[pos>>6] |= 1 << (pos & 63)
+	}
+}
+
+// Contains reports whether `data` is possibly in the filter.
+// It returns false only if `data` was definitely never added.
+func (b *Bloom) Contains(data []byte) bool {
+	h1, h2 := bloomHashes(b.hasher, data)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.bits[pos>>6]&(1<<(pos&63)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union merges `other` into the filter, then the filter contains the items of both filters.
+// It returns an error if the filters are not created with the same parameters and hasher.
+func (b *Bloom) Union(other *Bloom) error {
+	if err := b.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, w := range other.bits {
+		b.bits[i] |= w
+	}
+	return nil
+}
+
+// Intersect intersects the filter with `other`, then the filter contains only the items of both filters.
+// Note that the false-positive rate of the result is higher than a filter built from the common items.
+// It returns an error if the filters are not created with the same parameters and hasher.
+func (b *Bloom) Intersect(other *Bloom) error {
+	if err := b.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, w := range other.bits {
+		b.bits[i] &= w
+	}
+	return nil
+}
+
+// Cap returns the number of bits of the filter.
+func (b *Bloom) Cap() uint64 {
+	return b.m
+}
+
+// K returns the number of hash functions of the filter.
+func (b *Bloom) K() uint64 {
+	return b.k
+}
+
+// EstimatedCount returns the estimated number of distinct items added to the filter.
+func (b *Bloom) EstimatedCount() uint64 {
+	var set int
+	for _, w := range b.bits {
+		set += bits.OnesCount64(w)
+	}
+	return bloomEstimate(b.m, b.k, uint64(set))
+}
+
+// Reset removes all items from the filter.
+func (b *Bloom) Reset() {
+	clear(b.bits)
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 32+8*len(b.bits)), bloomMagic, b.hasher)
+	buf = binary.AppendUvarint(buf, b.m)
+	buf = binary.AppendUvarint(buf, b.k)
+	for _, w := range b.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, bloomMagic)
+	if err != nil {
+		return err
+	}
+	m, k := r.uvarint(), r.uvarint()
+	if r.err == nil && (m == 0 || !isValidBloomHashes(m, k) || uint64(len(r.data))/8 != (m-1)/64+1 || len(r.data)%8 != 0) {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for bloom filter")
+	}
+	words := make([]uint64, len(r.data)/8)
+	for i := range words {
+		words[i] = r.uint64()
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	*b = Bloom{hasher: hasher, m: m, k: k, bits: words}
+	return nil
+}
+
+// checkCompatible checks whether `other` has the same parameters and hasher as the filter.
+func (b *Bloom) checkCompatible(other *Bloom) error {
+	if other == nil || b.m != other.m || b.k != other.k || b.hasher.Name() != other.hasher.Name() {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "incompatible bloom filters")
+	}
+	return nil
+}
+
+// bloomParams returns the optimal number of bits `m` and hash functions `k`
+// for `n` expected items with false-positive rate `p`. The number of hash functions is at most bloomMaxHashes.
+func bloomParams(n uint64, p float64) (m, k uint64, err error) {
+	if n == 0 {
+		return 0, 0, minerror.NewCode(mincode.CodeInvalidParameter, "expected items of bloom filter should be positive")
+	}
+	if !(p > 0 && p < 1) {
+		return 0, 0, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid false-positive rate %v of bloom filter", p)
+	}
+	m = uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 1), min(max(k, 1), bloomMaxHashes), nil
+}
+
+// isValidBloomHashes checks whether `k` is a valid number of hash functions for `m` bits or counters.
+func isValidBloomHashes(m, k uint64) bool {
+	return k > 0 && k <= bloomMaxHashes && k <= m
+}
+
+// bloomHashes returns the two hash values for the double hashing of `data`,
+// the i-th location of `data` is (h1 + i*h2) mod m.
+func bloomHashes(hasher Hasher, data []byte) (h1, h2 uint64) {
+	h1 = hasher.Sum64(data)
+	h2 = murmur3Mix64(h1) | 1
+	return h1, h2
+}
+
+// bloomEstimate estimates the number of items from `set` bits of `m` bits with `k` hash functions.
+func bloomEstimate(m, k, set uint64) uint64 {
+	if set >= m {
+		return math.MaxUint64
+	}
+	return uint64(math.Round(-float64(m) / float64(k) * math.Log(1-float64(set)/float64(m))))
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// countingBloomMagic is the magic of the binary format of CountingBloom.
+	countingBloomMagic = "MCBM"
+)
+
+// CountingBloom is a counting Bloom filter, which replaces each bit of Bloom with a counter,
+// so that items can be removed. Counters saturate at 255 and are never decremented after that,
+// which keeps the filter free of false negatives.
+// It is not safe for concurrent use.
+type CountingBloom struct {
+	hasher   Hasher
+	m        uint64  // Number of counters.
+	k        uint64  // Number of hash functions.
+	counters []uint8 // Counter array.
+}
+
+// NewCountingBloom creates and returns a counting Bloom filter sized for `n` expected items
+// with false-positive rate `p`. The items are hashed by optional `hasher`, which is xxh64 in default.
+func NewCountingBloom(n uint64, p float64, hasher ...Hasher) (*CountingBloom, error) {
+	m, k, err := bloomParams(n, p)
+	if err != nil {
+		return nil, err
+	}
+	b := &CountingBloom{
+		hasher:   defaultHasher,
+		m:        m,
+		k:        k,
+		counters: make([]uint8, m),
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		b.hasher = hasher[0]
+	}
+	return b, nil
+}
+
+// Add adds `data` to the filter.
+func (b *CountingBloom) Add(data []byte) {
+	h1, h2 := bloomHashes(b.hasher, data)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.counters[pos] < math.MaxUint8 {
+			b.counters[pos]++
+		}
+	}
+}
+
+// Remove removes `data` from the filter.
+// It returns false and changes nothing if `data` is definitely not in the filter.
+// Note that removing an item that was never added may introduce false negatives.
+func (b *CountingBloom) Remove(data []byte) bool {
+	if !b.Contains(data) {
+		return false
+	}
+	h1, h2 := bloomHashes(b.hasher, data)
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.counters[pos] < math.MaxUint8 {
+			b.counters[pos]--
+		}
+	}
+	return true
+}
+
+// Contains reports whether `data` is possibly in the filter.
+// It returns false only if `data` is definitely not in the filter.
+func (b *CountingBloom) Contains(data []byte) bool {
+	h1, h2 := bloomHashes(b.hasher, data)
+	for i := uint64(0); i < b.k; i++ {
+		if b.counters[(h1+i*h2)%b.m] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union merges `other` into the filter by adding up their counters.
+// It returns an error if the filters are not created with the same parameters and hasher.
+func (b *CountingBloom) Union(other *CountingBloom) error {
+	if err := b.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, c := range other.counters {
+		b.counters[i] = uint8(min(int(b.counters[i])+int(c), math.MaxUint8))
+	}
+	return nil
+}
+
+// Intersect intersects the filter with `other` by keeping the minimum of their counters.
+// It returns an error if the filters are not created with the same parameters and hasher.
+func (b *CountingBloom) Intersect(other *CountingBloom) error {
+	if err := b.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, c := range other.counters {
+		b.counters[i] = min(b.counters[i], c)
+	}
+	return nil
+}
+
+// Cap returns the number of counters of the filter.
+func (b *CountingBloom) Cap() uint64 {
+	return b.m
+}
+
+// K returns the number of hash functions of the filter.
+func (b *CountingBloom) K() uint64 {
+	return b.k
+}
+
+// EstimatedCount returns the estimated number of distinct items in the filter.
+func (b *CountingBloom) EstimatedCount() uint64 {
+	var set uint64
+	for _, c := range b.counters {
+		if c > 0 {
+			set++
+		}
+	}
+	return bloomEstimate(b.m, b.k, set)
+}
+
+// Reset removes all items from the filter.
+func (b *CountingBloom) Reset() {
+	clear(b.counters)
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (b *CountingBloom) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 32+len(b.counters)), countingBloomMagic, b.hasher)
+	buf = binary.AppendUvarint(buf, b.m)
+	buf = binary.AppendUvarint(buf, b.k)
+	return append(buf, b.counters...), nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (b *CountingBloom) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, countingBloomMagic)
+	if err != nil {
+		return err
+	}
+	m, k := r.uvarint(), r.uvarint()
+	if r.err == nil && (m == 0 || !isValidBloomHashes(m, k) || uint64(len(r.data)) != m) {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for counting bloom filter")
+	}
+	counters := append([]uint8(nil), r.bytes(m)...)
+	if err = r.done(); err != nil {
+		return err
+	}
+	*b = CountingBloom{hasher: hasher, m: m, k: k, counters: counters}
+	return nil
+}
+
+// checkCompatible checks whether `other` has the same parameters and hasher as the filter.
+func (b *CountingBloom) checkCompatible(other *CountingBloom) error {
+	if other == nil || b.m != other.m || b.k != other.k || b.hasher.Name() != other.hasher.Name() {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "incompatible counting bloom filters")
+	}
+	return nil
+}
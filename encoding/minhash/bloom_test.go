@@ -0,0 +1,199 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Bloom_FalsePositiveRate(t *testing.T) {
+	const n = 20000
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		for _, name := range []string{"xxh64", "fnv1a32", "murmur3"} {
+			hasher, _ := minhash.Lookup(name)
+			b, err := minhash.NewBloom(n, p, hasher)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < n; i++ {
+				b.Add([]byte("member:" + strconv.Itoa(i)))
+			}
+			for i := 0; i < n; i++ {
+				if !b.Contains([]byte("member:" + strconv.Itoa(i))) {
+					t.Fatalf("%s %v: false negative of member %d", name, p, i)
+				}
+			}
+			var positives int
+			for i := 0; i < 10*n; i++ {
+				if b.Contains([]byte("other:" + strconv.Itoa(i))) {
+					positives++
+				}
+			}
+			// The rate is allowed a margin for the randomness of the sample.
+			if rate := float64(positives) / (10 * n); rate > 1.3*p {
+				t.Errorf("%s: false-positive rate %.5f, want about %v", name, rate, p)
+			}
+			if count := b.EstimatedCount(); count < n*95/100 || count > n*105/100 {
+				t.Errorf("%s %v: EstimatedCount = %d, want about %d", name, p, count, n)
+			}
+		}
+	}
+}
+
+func Test_Bloom_Params(t *testing.T) {
+	b, err := minhash.NewBloom(1000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Cap() != 9586 || b.K() != 7 {
+		t.Fatalf("NewBloom(1000, 0.01) = %d bits, %d hashes", b.Cap(), b.K())
+	}
+	if b, err = minhash.NewBloom(10, 1e-30); err != nil || b.K() != 64 {
+		t.Fatalf("K of tiny false-positive rate = %v, %v", b.K(), err)
+	}
+	for _, p := range []float64{0, 1, -0.5} {
+		if _, err = minhash.NewBloom(10, p); err == nil {
+			t.Errorf("NewBloom with rate %v succeeds", p)
+		}
+	}
+	if _, err = minhash.NewBloom(0, 0.01); err == nil {
+		t.Error("NewBloom of no item succeeds")
+	}
+}
+
+func Test_Bloom_SetOperations(t *testing.T) {
+	var (
+		b1, _ = minhash.NewBloom(1000, 0.001)
+		b2, _ = minhash.NewBloom(1000, 0.001)
+	)
+	b1.Add([]byte("a"))
+	b1.Add([]byte("b"))
+	b2.Add([]byte("b"))
+	b2.Add([]byte("c"))
+	union, _ := minhash.NewBloom(1000, 0.001)
+	if err := union.Union(b1); err != nil {
+		t.Fatal(err)
+	}
+	if err := union.Union(b2); err != nil {
+		t.Fatal(err)
+	}
+	if err := b1.Intersect(b2); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		if !union.Contains([]byte(key)) {
+			t.Errorf("union does not contain %s", key)
+		}
+	}
+	if !b1.Contains([]byte("b")) || b1.Contains([]byte("a")) || b1.Contains([]byte("c")) {
+		t.Error("intersection is wrong")
+	}
+	other, _ := minhash.NewBloom(1000, 0.01)
+	if b1.Union(other) == nil || b1.Intersect(nil) == nil {
+		t.Error("set operation of incompatible filters succeeds")
+	}
+	b1.Reset()
+	if b1.Contains([]byte("b")) || b1.EstimatedCount() != 0 {
+		t.Error("Reset does not remove items")
+	}
+}
+
+func Test_Bloom_Marshal(t *testing.T) {
+	fnv, _ := minhash.Lookup("fnv1a64")
+	b, _ := minhash.NewBloom(500, 0.01, fnv)
+	for i := 0; i < 500; i++ {
+		b.Add([]byte(strconv.Itoa(i)))
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result minhash.Bloom
+	if err = result.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if result.Cap() != b.Cap() || result.K() != b.K() || result.EstimatedCount() != b.EstimatedCount() {
+		t.Fatalf("UnmarshalBinary = %d bits, %d hashes", result.Cap(), result.K())
+	}
+	for i := 0; i < 500; i++ {
+		if !result.Contains([]byte(strconv.Itoa(i))) {
+			t.Fatalf("unmarshaled filter does not contain %d", i)
+		}
+	}
+	if err = result.Union(b); err != nil {
+		t.Fatalf("unmarshaled filter is incompatible: %v", err)
+	}
+	for _, k := range []uint64{0, 65, 1 << 40} {
+		if result.UnmarshalBinary(bloomData(64, k, 1)) == nil {
+			t.Errorf("UnmarshalBinary with %d hashes succeeds", k)
+		}
+	}
+	if result.UnmarshalBinary(bloomData(8, 9, 1)) == nil {
+		t.Error("UnmarshalBinary with more hashes than bits succeeds")
+	}
+	if result.UnmarshalBinary(bloomData(64, 3, 2)) == nil || result.UnmarshalBinary(data[:len(data)-1]) == nil {
+		t.Error("UnmarshalBinary of invalid size succeeds")
+	}
+	if err = result.UnmarshalBinary(bloomData(64, 64, 1)); err != nil {
+		t.Errorf("UnmarshalBinary with 64 hashes: %v", err)
+	}
+}
+
+// bloomData returns the binary data of Bloom with `m` bits, `k` hashes and `words` zero words.
+func bloomData(m, k uint64, words int) []byte {
+	data := append([]byte("MBLM\x01\x05xxh64"), binary.AppendUvarint(nil, m)...)
+	data = binary.AppendUvarint(data, k)
+	return append(data, make([]byte, 8*words)...)
+}
+
+func Test_CountingBloom(t *testing.T) {
+	b, err := minhash.NewCountingBloom(1000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		b.Add([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		if !b.Remove([]byte(strconv.Itoa(i))) {
+			t.Fatalf("Remove(%d) = false", i)
+		}
+	}
+	var positives int
+	for i := 0; i < 1000; i++ {
+		contains := b.Contains([]byte(strconv.Itoa(i)))
+		if i%2 == 1 && !contains {
+			t.Fatalf("false negative of %d after removing others", i)
+		}
+		if i%2 == 0 && contains {
+			positives++
+		}
+	}
+	if positives > 15 {
+		t.Errorf("%d removed items are still contained", positives)
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result minhash.CountingBloom
+	if err = result.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if result.Cap() != b.Cap() || result.K() != b.K() || result.EstimatedCount() != b.EstimatedCount() {
+		t.Fatalf("UnmarshalBinary = %d counters, %d hashes", result.Cap(), result.K())
+	}
+	// The number of hashes is the uvarint after m in the header.
+	invalid := append([]byte(nil), data...)
+	invalid[len(data)-int(b.Cap())-1] = 65
+	if result.UnmarshalBinary(invalid) == nil {
+		t.Error("UnmarshalBinary with 65 hashes succeeds")
+	}
+}
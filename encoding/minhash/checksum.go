@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+)
+
+var (
+	// crc32cTable is the table of the Castagnoli polynomial, which is hardware accelerated on most platforms.
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+	// crc64Table is the table of the ECMA polynomial.
+	crc64Table = crc64.MakeTable(crc64.ECMA)
+)
+
+// CRC32C implements the CRC-32 checksum algorithm with the Castagnoli polynomial.
+func CRC32C(str []byte) uint32 {
+	return crc32.Checksum(str, crc32cTable)
+}
+
+// CRC32CString is the same as CRC32C for string `str`, which hashes `str` without copying it.
+func CRC32CString(str string) uint32 {
+	return CRC32C(stringBytes(str))
+}
+
+// CRC64 implements the CRC-64 checksum algorithm with the ECMA polynomial.
+func CRC64(str []byte) uint64 {
+	return crc64.Checksum(str, crc64Table)
+}
+
+// CRC64String is the same as CRC64 for string `str`, which hashes `str` without copying it.
+func CRC64String(str string) uint64 {
+	return CRC64(stringBytes(str))
+}
+
+// Adler32 implements the Adler-32 checksum algorithm.
+func Adler32(str []byte) uint32 {
+	return adler32.Checksum(str)
+}
+
+// Adler32String is the same as Adler32 for string `str`, which hashes `str` without copying it.
+func Adler32String(str string) uint32 {
+	return Adler32(stringBytes(str))
+}
+
+// NewCRC32C returns a new hash.Hash32 computing the CRC-32 checksum with the Castagnoli polynomial.
+// Its Sum32 result is identical to CRC32C for the same written data.
+func NewCRC32C() hash.Hash32 {
+	return crc32.New(crc32cTable)
+}
+
+// NewCRC64 returns a new hash.Hash64 computing the CRC-64 checksum with the ECMA polynomial.
+// Its Sum64 result is identical to CRC64 for the same written data.
+func NewCRC64() hash.Hash64 {
+	return crc64.New(crc64Table)
+}
+
+// NewAdler32 returns a new hash.Hash32 computing the Adler-32 checksum.
+// Its Sum32 result is identical to Adler32 for the same written data.
+func NewAdler32() hash.Hash32 {
+	return adler32.New()
+}
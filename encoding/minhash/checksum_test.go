@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Checksum_Vectors(t *testing.T) {
+	// The check values of the catalogue of CRC algorithms, and the example of Adler-32.
+	if got := minhash.CRC32CString("123456789"); got != 0xe3069283 {
+		t.Errorf("CRC32C(123456789) = %#x", got)
+	}
+	if got := minhash.CRC64String("123456789"); got != 0x995dc9bbdf1939fa {
+		t.Errorf("CRC64(123456789) = %#x", got)
+	}
+	if got := minhash.Adler32String("Wikipedia"); got != 0x11e60398 {
+		t.Errorf("Adler32(Wikipedia) = %#x", got)
+	}
+}
+
+func Test_Checksum_Stdlib(t *testing.T) {
+	var (
+		crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+		crc64Table  = crc64.MakeTable(crc64.ECMA)
+		data        = chunkData(1<<16, 4)
+	)
+	for _, n := range []int{0, 1, 3, 8, 15, 64, 1000, 1 << 16} {
+		str := data[:n]
+		if got, want := minhash.CRC32C(str), crc32.Checksum(str, crc32cTable); got != want {
+			t.Errorf("CRC32C of %d bytes = %#x, want %#x", n, got, want)
+		}
+		if got, want := minhash.CRC64(str), crc64.Checksum(str, crc64Table); got != want {
+			t.Errorf("CRC64 of %d bytes = %#x, want %#x", n, got, want)
+		}
+		if got, want := minhash.Adler32(str), adler32.Checksum(str); got != want {
+			t.Errorf("Adler32 of %d bytes = %#x, want %#x", n, got, want)
+		}
+		if minhash.CRC32CString(string(str)) != minhash.CRC32C(str) ||
+			minhash.CRC64String(string(str)) != minhash.CRC64(str) ||
+			minhash.Adler32String(string(str)) != minhash.Adler32(str) {
+			t.Errorf("string checksums of %d bytes differ", n)
+		}
+	}
+}
+
+func Test_Checksum_Registry(t *testing.T) {
+	data := chunkData(5000, 5)
+	for name, sum := range map[string]uint64{
+		"crc32c":    uint64(minhash.CRC32C(data)),
+		"crc64ecma": minhash.CRC64(data),
+		"adler32":   uint64(minhash.Adler32(data)),
+	} {
+		hasher, err := minhash.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasher.Sum64(data) != sum {
+			t.Errorf("%s: Sum64 = %#x, want %#x", name, hasher.Sum64(data), sum)
+		}
+		// The streaming checksum of written pieces equals the one-shot one.
+		h := hasher.New()
+		for i := 0; i < len(data); i += 999 {
+			h.Write(data[i:min(i+999, len(data))])
+		}
+		digest := h.Sum(nil)
+		if len(digest) != hasher.Size() {
+			t.Fatalf("%s: digest size = %d, want %d", name, len(digest), hasher.Size())
+		}
+		var got uint64
+		for _, b := range digest {
+			got = got<<8 | uint64(b)
+		}
+		if got != sum {
+			t.Errorf("%s: streaming checksum = %#x, want %#x", name, got, sum)
+		}
+	}
+}
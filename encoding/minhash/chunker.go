@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"io"
+	"math/bits"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// defaultChunkMin is the default minimum chunk size.
+	defaultChunkMin = 2 << 10
+	// defaultChunkAvg is the default average chunk size.
+	defaultChunkAvg = 8 << 10
+	// defaultChunkMax is the default maximum chunk size.
+	defaultChunkMax = 64 << 10
+	// chunkSizeLimit is the limit of the maximum chunk size.
+	chunkSizeLimit = 1 << 30
+	// chunkNormalization is the normalization level, the number of mask bits adjusted around the average size.
+	chunkNormalization = 2
+)
+
+// Chunker splits a stream into content-defined chunks with the FastCDC algorithm, so that
+// an insertion or deletion only changes the chunks around it, which makes it suitable for deduplication.
+// It is not safe for concurrent use.
+type Chunker struct {
+	reader io.Reader
+	hasher Hasher
+	min    int
+	avg    int
+	max    int
+	maskS  uint64 // Harder mask used before the average size.
+	maskL  uint64 // Easier mask used after the average size.
+	buf    []byte // Buffered data of the stream.
+	start  int    // Start of the unconsumed data in buf.
+	end    int    // End of the unconsumed data in buf.
+	offset int64  // Offset of the unconsumed data in the stream.
+	err    error  // Error of reading the stream.
+}
+
+// ChunkerOption is the option for creating a Chunker.
+type ChunkerOption struct {
+	Hasher Hasher // Hash algorithm for the fingerprints of chunks, it is xxh64 in default.
+	Min    int    // Minimum chunk size, it is 2KiB in default.
+	Avg    int    // Average chunk size, it is 8KiB in default.
+	Max    int    // Maximum chunk size, it is 64KiB in default.
+}
+
+// Chunk is a content-defined chunk of a stream.
+type Chunk struct {
+	Offset int64  // Offset of the chunk in the stream.
+	Data   []byte // Content of the chunk, which is only valid until the next call of Chunker.Next.
+	Sum    uint64 // Fingerprint of the chunk by the hasher of the chunker.
+}
+
+// NewChunker creates and returns a Chunker splitting the stream of `reader`.
+// The chunk sizes must satisfy 0 < Min <= Avg <= Max <= 1GiB, and every chunk except the last one
+// has a size in [Min, Max].
+func NewChunker(reader io.Reader, option ...ChunkerOption) (*Chunker, error) {
+	c := &Chunker{
+		reader: reader,
+		hasher: defaultHasher,
+		min:    defaultChunkMin,
+		avg:    defaultChunkAvg,
+		max:    defaultChunkMax,
+	}
+	if len(option) > 0 {
+		if option[0].Hasher != nil {
+			c.hasher = option[0].Hasher
+		}
+		if option[0].Min > 0 {
+			c.min = option[0].Min
+		}
+		if option[0].Avg > 0 {
+			c.avg = option[0].Avg
+		}
+		if option[0].Max > 0 {
+			c.max = option[0].Max
+		}
+	}
+	if reader == nil {
+		return nil, minerror.NewCode(mincode.CodeInvalidParameter, "chunker reader should not be nil")
+	}
+	if c.min > c.avg || c.avg > c.max || c.max > chunkSizeLimit {
+		return nil, minerror.NewCodef(
+			mincode.CodeInvalidParameter, "invalid chunk sizes min %d, avg %d, max %d", c.min, c.avg, c.max,
+		)
+	}
+	// The gear hash shifts left for each byte, so its high bits depend on the most recent 64 bytes.
+	avgBits := bits.Len(uint(c.avg)) - 1
+	c.maskS = chunkMask(avgBits + chunkNormalization)
+	c.maskL = chunkMask(avgBits - chunkNormalization)
+	c.buf = make([]byte, 2*c.max)
+	return c, nil
+}
+
+// Next returns the next chunk of the stream, or io.EOF if there are no more chunks.
+// It returns the error of the reader if reading the stream fails.
+func (c *Chunker) Next() (Chunk, error) {
+	if err := c.fill(); err != nil {
+		return Chunk{}, err
+	}
+	var (
+		data  = c.buf[c.start:c.end]
+		n     = c.cut(data)
+		chunk = Chunk{
+			Offset: c.offset,
+			Data:   data[:n],
+			Sum:    c.hasher.Sum64(data[:n]),
+		}
+	)
+	c.start += n
+	c.offset += int64(n)
+	return chunk, nil
+}
+
+// fill reads the stream until there are at least max bytes of unconsumed data or the stream ends.
+func (c *Chunker) fill() error {
+	if c.end-c.start >= c.max {
+		return nil
+	}
+	if c.err == nil {
+		// Move the unconsumed data to the front, so that the buffer has room for max bytes.
+		c.end = copy(c.buf, c.buf[c.start:c.end])
+		c.start = 0
+		for c.end < len(c.buf) && c.err == nil {
+			var n int
+			n, c.err = c.reader.Read(c.buf[c.end:])
+			c.end += n
+		}
+	}
+	if c.start < c.end {
+		return nil
+	}
+	if c.err == io.EOF {
+		return io.EOF
+	}
+	return minerror.Wrap(c.err, "read chunker stream failed")
+}
+
+// cut returns the size of the next chunk at the start of `data`.
+func (c *Chunker) cut(data []byte) int {
+	n := len(data)
+	if n <= c.min {
+		return n
+	}
+	n = min(n, c.max)
+	var (
+		fp     uint64
+		i      = c.min
+		normal = min(c.avg, n)
+	)
+	for ; i < normal; i++ {
+		fp = fp<<1 + rollingTable[data[i]]
+		if fp&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		fp = fp<<1 + rollingTable[data[i]]
+		if fp&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
+
+// chunkMask returns the mask of the highest `n` bits, n is limited in [1, 63].
+func chunkMask(n int) uint64 {
+	n = min(max(n, 1), 63)
+	return ^uint64(0) << (64 - n)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// chunkData returns `n` pseudo-random bytes, which are fixed for the same `seed`.
+func chunkData(n int, seed int64) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+// chunkAll splits `reader` with `option`, and returns the chunks with their data copied.
+func chunkAll(t *testing.T, reader io.Reader, option ...minhash.ChunkerOption) []minhash.Chunk {
+	t.Helper()
+	c, err := minhash.NewChunker(reader, option...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chunks []minhash.Chunk
+	for {
+		chunk, err := c.Next()
+		if err == io.EOF {
+			return chunks
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunk.Data = append([]byte(nil), chunk.Data...)
+		chunks = append(chunks, chunk)
+	}
+}
+
+// chunkOffsets returns the offsets of `chunks`.
+func chunkOffsets(chunks []minhash.Chunk) []int64 {
+	offsets := make([]int64, len(chunks))
+	for i, chunk := range chunks {
+		offsets[i] = chunk.Offset
+	}
+	return offsets
+}
+
+func Test_Chunker_Vectors(t *testing.T) {
+	// The boundaries are checked against an independent implementation of FastCDC with the same gear table.
+	data := chunkData(256<<10, 1)
+	for _, c := range []struct {
+		option  minhash.ChunkerOption
+		offsets []int64
+	}{
+		{minhash.ChunkerOption{}, []int64{
+			0, 4832, 10321, 18706, 27659, 37803, 50359, 61340, 71350, 78719, 88295, 96494, 110928, 113272, 124119, 137453,
+			149827, 159570, 169093, 179243, 184826, 194306, 202802, 215493, 225560, 231436, 240284, 243986, 247831, 250713,
+			261858,
+		}},
+		{minhash.ChunkerOption{Min: 64, Avg: 256, Max: 1024}, []int64{0, 336, 734, 1164, 1429, 1715, 2144, 2535, 2902, 3176, 3494, 3706}},
+	} {
+		offsets := chunkOffsets(chunkAll(t, bytes.NewReader(data), c.option))
+		if len(offsets) > len(c.offsets) {
+			offsets = offsets[:len(c.offsets)]
+		}
+		if !reflect.DeepEqual(offsets, c.offsets) {
+			t.Errorf("%+v: offsets = %v, want %v", c.option, offsets, c.offsets)
+		}
+	}
+}
+
+func Test_Chunker_Sizes(t *testing.T) {
+	var (
+		data   = chunkData(1<<20, 2)
+		option = minhash.ChunkerOption{Min: 1024, Avg: 4096, Max: 16384}
+		// The reader returns one byte at a time, which does not change the chunks.
+		chunks = chunkAll(t, iotest.OneByteReader(bytes.NewReader(data)), option)
+		joined []byte
+	)
+	for i, chunk := range chunks {
+		if i < len(chunks)-1 && (len(chunk.Data) < option.Min || len(chunk.Data) > option.Max) {
+			t.Fatalf("chunk %d has size %d", i, len(chunk.Data))
+		}
+		if chunk.Offset != int64(len(joined)) || chunk.Sum != minhash.XXH64(chunk.Data) {
+			t.Fatalf("chunk %d has offset %d, sum %#x", i, chunk.Offset, chunk.Sum)
+		}
+		joined = append(joined, chunk.Data...)
+	}
+	if !bytes.Equal(joined, data) {
+		t.Fatal("the chunks do not reassemble the stream")
+	}
+	if avg := len(data) / len(chunks); avg < 3000 || avg > 6000 {
+		t.Errorf("average chunk size = %d, want about %d", avg, option.Avg)
+	}
+	if !reflect.DeepEqual(chunkOffsets(chunks), chunkOffsets(chunkAll(t, bytes.NewReader(data), option))) {
+		t.Fatal("the chunks depend on the reads of the reader")
+	}
+}
+
+func Test_Chunker_Shift(t *testing.T) {
+	var (
+		data    = chunkData(512<<10, 3)
+		edited  = append(append(append([]byte(nil), data[:100000]...), "inserted"...), data[100000:]...)
+		sums    = make(map[uint64]bool)
+		changed int
+	)
+	for _, chunk := range chunkAll(t, bytes.NewReader(data)) {
+		sums[chunk.Sum] = true
+	}
+	chunks := chunkAll(t, bytes.NewReader(edited))
+	for _, chunk := range chunks {
+		if !sums[chunk.Sum] {
+			changed++
+		}
+	}
+	// An insertion only changes the chunks around it.
+	if changed > 2 {
+		t.Fatalf("%d of %d chunks are changed by an insertion", changed, len(chunks))
+	}
+}
+
+func Test_Chunker_Invalid(t *testing.T) {
+	if _, err := minhash.NewChunker(nil); err == nil {
+		t.Error("NewChunker of nil reader succeeds")
+	}
+	for _, option := range []minhash.ChunkerOption{
+		{Min: 4096, Avg: 1024},
+		{Avg: 128 << 10},
+		{Min: 1, Avg: 2, Max: 2 << 30},
+	} {
+		if _, err := minhash.NewChunker(bytes.NewReader(nil), option); err == nil {
+			t.Errorf("NewChunker with %+v succeeds", option)
+		}
+	}
+	if chunks := chunkAll(t, bytes.NewReader(nil)); len(chunks) != 0 {
+		t.Errorf("chunks of empty stream = %d", len(chunks))
+	}
+	failure := errors.New("failure")
+	c, _ := minhash.NewChunker(iotest.ErrReader(failure))
+	if _, err := c.Next(); !errors.Is(err, failure) {
+		t.Errorf("Next = %v, want the error of the reader", err)
+	}
+}
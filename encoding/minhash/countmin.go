@@ -0,0 +1,176 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// countMinMagic is the magic of the binary format of CountMinSketch.
+const countMinMagic = "MCMS"
+
+// CountMinSketch is a Count-Min sketch, which estimates the frequency of items in a stream
+// with sub-linear memory. The estimation never underestimates, and overestimates by at most
+// epsilon*Total with probability 1-delta, where width = e/epsilon and depth = ln(1/delta).
+// The columns of the rows are derived by double hashing from the hash of the hasher only, so a keyed
+// hasher keeps the columns unpredictable, and the items colliding in the hash of the hasher share their counters.
+// It is not safe for concurrent use.
+type CountMinSketch struct {
+	hasher Hasher
+	width  uint64   // Number of counters in each row.
+	depth  uint64   // Number of rows.
+	counts []uint64 // Counters of all rows, row by row.
+	total  uint64   // Total count of all added items.
+}
+
+// NewCountMinSketch creates and returns a Count-Min sketch with `width` counters per row and `depth` rows.
+// The items are hashed by optional `hasher`, which is xxh64 in default.
+func NewCountMinSketch(width, depth uint64, hasher ...Hasher) (*CountMinSketch, error) {
+	if width == 0 || depth == 0 {
+		return nil, minerror.NewCodef(
+			mincode.CodeInvalidParameter, "invalid count-min sketch size %dx%d", width, depth,
+		)
+	}
+	s := &CountMinSketch{
+		hasher: defaultHasher,
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		s.hasher = hasher[0]
+	}
+	return s, nil
+}
+
+// NewCountMinSketchWithEstimates creates and returns a Count-Min sketch whose estimation error is
+// at most `epsilon`*Total with probability 1-`delta`.
+func NewCountMinSketchWithEstimates(epsilon, delta float64, hasher ...Hasher) (*CountMinSketch, error) {
+	if !(epsilon > 0 && epsilon < 1) || !(delta > 0 && delta < 1) {
+		return nil, minerror.NewCodef(
+			mincode.CodeInvalidParameter, "invalid count-min sketch estimates epsilon %v, delta %v", epsilon, delta,
+		)
+	}
+	return NewCountMinSketch(
+		uint64(math.Ceil(math.E/epsilon)),
+		uint64(math.Ceil(math.Log(1/delta))),
+		hasher...,
+	)
+}
+
+// Add adds `data` to the sketch with optional `count`, which is 1 in default.
+func (s *CountMinSketch) Add(data []byte, count ...uint64) {
+	c := uint64(1)
+	if len(count) > 0 {
+		c = count[0]
+	}
+	h1, h2 := s.hashes(data)
+	for row := uint64(0); row < s.depth; row++ {
+		s.counts[row*s.width+s.column(h1, h2, row)] += c
+	}
+	s.total += c
+}
+
+// Count returns the estimated count of `data`.
+func (s *CountMinSketch) Count(data []byte) uint64 {
+	var (
+		h1, h2 = s.hashes(data)
+		result = uint64(math.MaxUint64)
+	)
+	for row := uint64(0); row < s.depth; row++ {
+		result = min(result, s.counts[row*s.width+s.column(h1, h2, row)])
+	}
+	return result
+}
+
+// Total returns the total count of all added items.
+func (s *CountMinSketch) Total() uint64 {
+	return s.total
+}
+
+// Width returns the number of counters in each row.
+func (s *CountMinSketch) Width() uint64 {
+	return s.width
+}
+
+// Depth returns the number of rows.
+func (s *CountMinSketch) Depth() uint64 {
+	return s.depth
+}
+
+// Merge merges `other` into the sketch by adding up their counters, then the sketch
+// estimates the counts of both sketches. It is used to combine sketches of different instances.
+// It returns an error if the sketches do not have the same size and hasher.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if other == nil || s.width != other.width || s.depth != other.depth || s.hasher.Name() != other.hasher.Name() {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "incompatible count-min sketches")
+	}
+	for i, c := range other.counts {
+		s.counts[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+// Reset removes all items from the sketch.
+func (s *CountMinSketch) Reset() {
+	clear(s.counts)
+	s.total = 0
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(nil, countMinMagic, s.hasher)
+	buf = binary.AppendUvarint(buf, s.width)
+	buf = binary.AppendUvarint(buf, s.depth)
+	buf = binary.AppendUvarint(buf, s.total)
+	for _, c := range s.counts {
+		buf = binary.AppendUvarint(buf, c)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (s *CountMinSketch) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, countMinMagic)
+	if err != nil {
+		return err
+	}
+	width, depth, total := r.uvarint(), r.uvarint(), r.uvarint()
+	if r.err != nil {
+		return r.err
+	}
+	// Each counter takes at least one byte.
+	if width == 0 || depth == 0 || width > uint64(len(r.data)) || depth > uint64(len(r.data))/width {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for count-min sketch")
+	}
+	counts := make([]uint64, width*depth)
+	for i := range counts {
+		counts[i] = r.uvarint()
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	*s = CountMinSketch{hasher: hasher, width: width, depth: depth, counts: counts, total: total}
+	return nil
+}
+
+// hashes returns the two hashes of `data` for the double hashing of the rows.
+// The second hash is mixed from the hash of the hasher, which is odd to visit all columns of a power of 2 width.
+func (s *CountMinSketch) hashes(data []byte) (h1, h2 uint64) {
+	h1 = s.hasher.Sum64(data)
+	return h1, murmur3Mix64(h1^goldenRatio64) | 1
+}
+
+// column returns the column of `row` for the hashes `h1` and `h2`, which is derived from h1 + row*h2.
+func (s *CountMinSketch) column(h1, h2, row uint64) uint64 {
+	return murmur3Mix64(h1+row*h2) % s.width
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// zipfKeys returns a stream of `n` keys drawn from `keys` distinct keys with the Zipf distribution,
+// which is fixed by `seed`, and the real counts of the keys.
+func zipfKeys(n, keys int, seed int64) ([]string, map[string]uint64) {
+	var (
+		r      = rand.New(rand.NewSource(seed))
+		zipf   = rand.NewZipf(r, 1.1, 1, uint64(keys-1))
+		stream = make([]string, n)
+		counts = make(map[string]uint64)
+	)
+	for i := range stream {
+		stream[i] = "key:" + strconv.FormatUint(zipf.Uint64(), 10)
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+func Test_CountMinSketch_ErrorBound(t *testing.T) {
+	const epsilon, delta = 0.001, 0.01
+	stream, counts := zipfKeys(200000, 20000, 1)
+	for _, name := range []string{"xxh64", "fnv1a32", "murmur3", "sdbm64"} {
+		hasher, _ := minhash.Lookup(name)
+		s, err := minhash.NewCountMinSketchWithEstimates(epsilon, delta, hasher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range stream {
+			s.Add([]byte(key))
+		}
+		var (
+			bound    = uint64(epsilon * float64(s.Total()))
+			exceeded int
+		)
+		for key, count := range counts {
+			estimate := s.Count([]byte(key))
+			if estimate < count {
+				t.Fatalf("%s: Count(%s) = %d, underestimates %d", name, key, estimate, count)
+			}
+			if estimate-count > bound {
+				exceeded++
+			}
+		}
+		if rate := float64(exceeded) / float64(len(counts)); rate > delta {
+			t.Errorf("%s: %.4f of the keys exceed the error bound %d, want at most %v", name, rate, bound, delta)
+		}
+	}
+}
+
+func Test_CountMinSketch_Merge(t *testing.T) {
+	var (
+		stream, counts = zipfKeys(20000, 2000, 2)
+		s1, _          = minhash.NewCountMinSketch(512, 4)
+		s2, _          = minhash.NewCountMinSketch(512, 4)
+		all, _         = minhash.NewCountMinSketch(512, 4)
+	)
+	for i, key := range stream {
+		if i%2 == 0 {
+			s1.Add([]byte(key))
+		} else {
+			s2.Add([]byte(key), 1)
+		}
+		all.Add([]byte(key))
+	}
+	if err := s1.Merge(s2); err != nil {
+		t.Fatal(err)
+	}
+	if s1.Total() != uint64(len(stream)) {
+		t.Fatalf("Total = %d, want %d", s1.Total(), len(stream))
+	}
+	for key := range counts {
+		if s1.Count([]byte(key)) != all.Count([]byte(key)) {
+			t.Fatalf("merged Count(%s) = %d, want %d", key, s1.Count([]byte(key)), all.Count([]byte(key)))
+		}
+	}
+	fnv, _ := minhash.Lookup("fnv1a64")
+	for _, other := range []*minhash.CountMinSketch{
+		nil,
+		func() *minhash.CountMinSketch { s, _ := minhash.NewCountMinSketch(256, 4); return s }(),
+		func() *minhash.CountMinSketch { s, _ := minhash.NewCountMinSketch(512, 4, fnv); return s }(),
+	} {
+		if s1.Merge(other) == nil {
+			t.Error("Merge of incompatible sketch succeeds")
+		}
+	}
+}
+
+func Test_CountMinSketch_Marshal(t *testing.T) {
+	stream, counts := zipfKeys(5000, 500, 3)
+	s, _ := minhash.NewCountMinSketch(128, 3)
+	for _, key := range stream {
+		s.Add([]byte(key))
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result minhash.CountMinSketch
+	if err = result.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if result.Width() != 128 || result.Depth() != 3 || result.Total() != s.Total() {
+		t.Fatalf("UnmarshalBinary = %dx%d total %d", result.Width(), result.Depth(), result.Total())
+	}
+	for key := range counts {
+		if result.Count([]byte(key)) != s.Count([]byte(key)) {
+			t.Fatalf("Count(%s) = %d, want %d", key, result.Count([]byte(key)), s.Count([]byte(key)))
+		}
+	}
+	for _, invalid := range [][]byte{nil, data[:len(data)-1], append(append([]byte(nil), data...), 0)} {
+		if result.UnmarshalBinary(invalid) == nil {
+			t.Errorf("UnmarshalBinary of %d bytes succeeds", len(invalid))
+		}
+	}
+	if _, err = minhash.NewCountMinSketch(0, 1); err == nil {
+		t.Fatal("NewCountMinSketch of zero width succeeds")
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// cuckooMagic is the magic of the binary format of Cuckoo.
+	cuckooMagic = "MCKO"
+	// defaultCuckooFingerprintBits is the default number of bits of fingerprints.
+	defaultCuckooFingerprintBits = 16
+	// defaultCuckooBucketSize is the default number of fingerprints in each bucket.
+	defaultCuckooBucketSize = 4
+	// cuckooMaxKicks is the maximum number of relocations for an insertion.
+	cuckooMaxKicks = 500
+)
+
+// Cuckoo is a cuckoo filter, which is a probabilistic set supporting deletion.
+// Each item is stored as a fingerprint in one of its two candidate buckets, and its false-positive
+// rate is about 2*BucketSize/2^FingerprintBits.
+// It is not safe for concurrent use.
+type Cuckoo struct {
+	hasher     Hasher
+	fpBits     uint64   // Number of bits of fingerprints.
+	bucketSize uint64   // Number of fingerprints in each bucket.
+	buckets    uint64   // Number of buckets, which is a power of two.
+	slots      []uint64 // Fingerprints of all buckets packed by bits, 0 is an empty slot.
+	count      uint64   // Number of stored items, including the victim.
+	victim     cuckooVictim
+	kicks      uint64 // Sequence for choosing the slots to relocate.
+}
+
+// CuckooOption is the option for creating a Cuckoo.
+type CuckooOption struct {
+	Hasher          Hasher // Hash algorithm, it is xxh64 in default.
+	FingerprintBits int    // Number of bits of fingerprints in range [4, 32], it is 16 in default.
+	BucketSize      int    // Number of fingerprints in each bucket in range [1, 8], it is 4 in default.
+}
+
+// cuckooVictim is the fingerprint which failed to be relocated when the filter is full,
+// it is kept so that no inserted item is lost.
+type cuckooVictim struct {
+	used        bool
+	index       uint64
+	fingerprint uint64
+}
+
+// NewCuckoo creates and returns a cuckoo filter sized for `capacity` items.
+// The number of buckets is rounded up to a power of two, so the actual capacity may be larger.
+func NewCuckoo(capacity uint64, option ...CuckooOption) (*Cuckoo, error) {
+	c := &Cuckoo{
+		hasher:     defaultHasher,
+		fpBits:     defaultCuckooFingerprintBits,
+		bucketSize: defaultCuckooBucketSize,
+	}
+	if len(option) > 0 {
+		if option[0].Hasher != nil {
+			c.hasher = option[0].Hasher
+		}
+		if option[0].FingerprintBits != 0 {
+			c.fpBits = uint64(option[0].FingerprintBits)
+		}
+		if option[0].BucketSize != 0 {
+			c.bucketSize = uint64(option[0].BucketSize)
+		}
+	}
+	if capacity == 0 || capacity > 1<<40 {
+		return nil, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid cuckoo filter capacity %d", capacity)
+	}
+	if c.fpBits < 4 || c.fpBits > 32 || c.bucketSize < 1 || c.bucketSize > 8 {
+		return nil, minerror.NewCodef(
+			mincode.CodeInvalidParameter, "invalid cuckoo filter fingerprint bits %d with bucket size %d",
+			c.fpBits, c.bucketSize,
+		)
+	}
+	buckets := (capacity + c.bucketSize - 1) / c.bucketSize
+	c.buckets = uint64(1) << bits.Len64(max(buckets, 2)-1)
+	c.slots = make([]uint64, cuckooWords(c.buckets, c.bucketSize, c.fpBits))
+	return c, nil
+}
+
+// Insert inserts `data` into the filter. An item can be inserted multiple times,
+// and should be deleted as many times.
+// It returns false if the filter is full, in which case `data` is not inserted.
+func (c *Cuckoo) Insert(data []byte) bool {
+	if c.victim.used {
+		return false
+	}
+	i1, fp := c.hashes(data)
+	i2 := c.altIndex(i1, fp)
+	if c.insertTo(i1, fp) || c.insertTo(i2, fp) {
+		c.count++
+		return true
+	}
+	// Relocate fingerprints to their alternate buckets to make room.
+	index := i1
+	if c.nextKick()&1 == 1 {
+		index = i2
+	}
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := index*c.bucketSize + c.nextKick()%c.bucketSize
+		evicted := c.get(slot)
+		c.set(slot, fp)
+		fp = evicted
+		index = c.altIndex(index, fp)
+		if c.insertTo(index, fp) {
+			c.count++
+			return true
+		}
+	}
+	c.victim = cuckooVictim{used: true, index: index, fingerprint: fp}
+	c.count++
+	return true
+}
+
+// Lookup reports whether `data` is possibly in the filter.
+// It returns false only if `data` is definitely not in the filter.
+func (c *Cuckoo) Lookup(data []byte) bool {
+	i1, fp := c.hashes(data)
+	i2 := c.altIndex(i1, fp)
+	if c.victim.used && c.victim.fingerprint == fp && (c.victim.index == i1 || c.victim.index == i2) {
+		return true
+	}
+	_, ok1 := c.find(i1, fp)
+	_, ok2 := c.find(i2, fp)
+	return ok1 || ok2
+}
+
+// Delete deletes one copy of `data` from the filter, and returns whether it was found.
+// Note that deleting an item which was never inserted may delete another item with the same fingerprint.
+func (c *Cuckoo) Delete(data []byte) bool {
+	i1, fp := c.hashes(data)
+	i2 := c.altIndex(i1, fp)
+	if c.victim.used && c.victim.fingerprint == fp && (c.victim.index == i1 || c.victim.index == i2) {
+		c.victim = cuckooVictim{}
+		c.count--
+		return true
+	}
+	for _, index := range [2]uint64{i1, i2} {
+		if slot, ok := c.find(index, fp); ok {
+			c.set(slot, 0)
+			c.count--
+			c.reinsertVictim()
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of items in the filter.
+func (c *Cuckoo) Count() uint64 {
+	return c.count
+}
+
+// Cap returns the number of fingerprint slots of the filter.
+func (c *Cuckoo) Cap() uint64 {
+	return c.buckets * c.bucketSize
+}
+
+// LoadFactor returns the fraction of occupied slots, insertions are likely to fail above 0.95
+// with the default bucket size.
+func (c *Cuckoo) LoadFactor() float64 {
+	return float64(c.count) / float64(c.Cap())
+}
+
+// Reset removes all items from the filter.
+func (c *Cuckoo) Reset() {
+	clear(c.slots)
+	c.count, c.victim = 0, cuckooVictim{}
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (c *Cuckoo) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 48+8*len(c.slots)), cuckooMagic, c.hasher)
+	buf = binary.AppendUvarint(buf, c.fpBits)
+	buf = binary.AppendUvarint(buf, c.bucketSize)
+	buf = binary.AppendUvarint(buf, c.buckets)
+	buf = binary.AppendUvarint(buf, c.count)
+	if c.victim.used {
+		buf = append(buf, 1)
+		buf = binary.AppendUvarint(buf, c.victim.index)
+		buf = binary.AppendUvarint(buf, c.victim.fingerprint)
+	} else {
+		buf = append(buf, 0)
+	}
+	for _, w := range c.slots {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (c *Cuckoo) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, cuckooMagic)
+	if err != nil {
+		return err
+	}
+	result := &Cuckoo{
+		hasher:     hasher,
+		fpBits:     r.uvarint(),
+		bucketSize: r.uvarint(),
+		buckets:    r.uvarint(),
+		count:      r.uvarint(),
+	}
+	if flag := r.bytes(1); r.err == nil && flag[0] == 1 {
+		result.victim = cuckooVictim{used: true, index: r.uvarint(), fingerprint: r.uvarint()}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if result.fpBits < 4 || result.fpBits > 32 || result.bucketSize < 1 || result.bucketSize > 8 ||
+		result.buckets < 2 || result.buckets&(result.buckets-1) != 0 || result.buckets > uint64(len(r.data)) ||
+		uint64(len(r.data))/8 != cuckooWords(result.buckets, result.bucketSize, result.fpBits) ||
+		len(r.data)%8 != 0 || result.count > result.Cap()+1 || result.victim.index >= result.buckets {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for cuckoo filter")
+	}
+	result.slots = make([]uint64, len(r.data)/8)
+	for i := range result.slots {
+		result.slots[i] = r.uint64()
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	*c = *result
+	return nil
+}
+
+// hashes returns the primary bucket index and the non-zero fingerprint of `data`.
+func (c *Cuckoo) hashes(data []byte) (index, fingerprint uint64) {
+	hash := c.hasher.Sum64(data)
+	// The fingerprint is taken from the mixed hash, so that it is independent of the index
+	// for both 32 and 64 bits algorithms.
+	fingerprint = murmur3Mix64(hash) >> (64 - c.fpBits)
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	return hash & (c.buckets - 1), fingerprint
+}
+
+// altIndex returns the alternate bucket index of `fingerprint` in bucket `index`,
+// which is symmetric so that either index can be computed from the other one.
+func (c *Cuckoo) altIndex(index, fingerprint uint64) uint64 {
+	return (index ^ murmur3Mix64(fingerprint)) & (c.buckets - 1)
+}
+
+// insertTo inserts `fingerprint` into an empty slot of bucket `index`, and returns whether it succeeds.
+func (c *Cuckoo) insertTo(index, fingerprint uint64) bool {
+	for slot := index * c.bucketSize; slot < (index+1)*c.bucketSize; slot++ {
+		if c.get(slot) == 0 {
+			c.set(slot, fingerprint)
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the slot of `fingerprint` in bucket `index` and whether it is found.
+func (c *Cuckoo) find(index, fingerprint uint64) (uint64, bool) {
+	for slot := index * c.bucketSize; slot < (index+1)*c.bucketSize; slot++ {
+		if c.get(slot) == fingerprint {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+// reinsertVictim tries to move the victim into the buckets after a deletion.
+func (c *Cuckoo) reinsertVictim() {
+	if !c.victim.used {
+		return
+	}
+	v := c.victim
+	if c.insertTo(v.index, v.fingerprint) || c.insertTo(c.altIndex(v.index, v.fingerprint), v.fingerprint) {
+		c.victim = cuckooVictim{}
+	}
+}
+
+// nextKick returns the next pseudo-random number for relocations, which is deterministic.
+func (c *Cuckoo) nextKick() uint64 {
+	c.kicks++
+	return murmur3Mix64(c.kicks)
+}
+
+// get returns the fingerprint of `slot`.
+func (c *Cuckoo) get(slot uint64) uint64 {
+	var (
+		pos  = slot * c.fpBits
+		word = pos >> 6
+		off  = pos & 63
+		mask = uint64(1)<<c.fpBits - 1
+		v    = c.slots[word] >> off
+	)
+	if off+c.fpBits > 64 {
+		v |= c.slots[word+1] << (64 - off)
+	}
+	return v & mask
+}
+
+// set sets the fingerprint of `slot` to `fingerprint`.
+func (c *Cuckoo) set(slot, fingerprint uint64) {
+	var (
+		pos  = slot * c.fpBits
+		word = pos >> 6
+		off  = pos & 63
+		mask = uint64(1)<<c.fpBits - 1
+	)
+	c.slots[word] = c.slots[word]&^(mask<<off) | fingerprint<<off
+	if off+c.fpBits > 64 {
+		shift := 64 - off
+		c.slots[word+1] = c.slots[word+1]&^(mask>>shift) | fingerprint>>shift
+	}
+}
+
+// cuckooWords returns the number of words for the slots of `buckets` buckets.
+func cuckooWords(buckets, bucketSize, fpBits uint64) uint64 {
+	return (buckets*bucketSize*fpBits + 63) / 64
+}
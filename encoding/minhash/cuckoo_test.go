@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Cuckoo_Fill(t *testing.T) {
+	c, err := minhash.NewCuckoo(1 << 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inserted int
+	for c.Insert([]byte("item:" + strconv.Itoa(inserted))) {
+		inserted++
+	}
+	// The last inserted item may be kept as the victim, which is still found.
+	for i := 0; i < inserted; i++ {
+		if !c.Lookup([]byte("item:" + strconv.Itoa(i))) {
+			t.Fatalf("false negative of item %d", i)
+		}
+	}
+	if c.Count() != uint64(inserted) || c.LoadFactor() < 0.9 {
+		t.Fatalf("filter is full at %d items, load factor %.3f", inserted, c.LoadFactor())
+	}
+	// Deleting items makes room for insertions again, once the victim is moved back into the buckets.
+	deleted := 0
+	for ; deleted < inserted && !c.Insert([]byte("again")); deleted++ {
+		if !c.Delete([]byte("item:" + strconv.Itoa(deleted))) {
+			t.Fatalf("Delete of item %d fails", deleted)
+		}
+	}
+	if !c.Lookup([]byte("again")) || c.Count() != uint64(inserted-deleted+1) {
+		t.Fatalf("insertion after deleting %d items fails", deleted)
+	}
+	for i := deleted; i < inserted; i++ {
+		if !c.Lookup([]byte("item:" + strconv.Itoa(i))) {
+			t.Fatalf("false negative of item %d after deletion", i)
+		}
+	}
+}
+
+func Test_Cuckoo_FalsePositiveRate(t *testing.T) {
+	const n = 10000
+	c, err := minhash.NewCuckoo(n, minhash.CuckooOption{FingerprintBits: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		c.Insert([]byte("member:" + strconv.Itoa(i)))
+	}
+	var positives int
+	for i := 0; i < 10*n; i++ {
+		if c.Lookup([]byte("other:" + strconv.Itoa(i))) {
+			positives++
+		}
+	}
+	// The rate is at most 2*BucketSize/2^FingerprintBits, which is 0.03125.
+	if rate := float64(positives) / (10 * n); rate > 0.03125 {
+		t.Fatalf("false-positive rate %.5f", rate)
+	}
+}
+
+func Test_Cuckoo_Delete(t *testing.T) {
+	c, _ := minhash.NewCuckoo(1000)
+	for _, item := range []string{"a", "b", "b"} {
+		c.Insert([]byte(item))
+	}
+	if !c.Delete([]byte("b")) || !c.Lookup([]byte("b")) {
+		t.Fatal("deleting one copy of an item deletes all copies")
+	}
+	if !c.Delete([]byte("b")) || c.Lookup([]byte("b")) || c.Delete([]byte("b")) {
+		t.Fatal("deleted item is still found")
+	}
+	if !c.Lookup([]byte("a")) || c.Count() != 1 {
+		t.Fatalf("Count = %d after deletion", c.Count())
+	}
+	c.Reset()
+	if c.Lookup([]byte("a")) || c.Count() != 0 {
+		t.Fatal("Reset does not remove items")
+	}
+}
+
+func Test_Cuckoo_Marshal(t *testing.T) {
+	for _, option := range []minhash.CuckooOption{{}, {FingerprintBits: 12, BucketSize: 2}, {FingerprintBits: 32, BucketSize: 8}} {
+		c, err := minhash.NewCuckoo(512, option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The filter is filled up, so that the victim is also marshaled.
+		var inserted int
+		for c.Insert([]byte(strconv.Itoa(inserted))) {
+			inserted++
+		}
+		data, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result minhash.Cuckoo
+		if err = result.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%+v: UnmarshalBinary failed: %v", option, err)
+		}
+		if result.Count() != c.Count() || result.Cap() != c.Cap() {
+			t.Fatalf("%+v: UnmarshalBinary = %d items of %d", option, result.Count(), result.Cap())
+		}
+		for i := 0; i < inserted; i++ {
+			if !result.Lookup([]byte(strconv.Itoa(i))) {
+				t.Fatalf("%+v: unmarshaled filter does not contain %d", option, i)
+			}
+		}
+		if result.UnmarshalBinary(data[:len(data)-1]) == nil {
+			t.Fatalf("%+v: UnmarshalBinary of truncated data succeeds", option)
+		}
+	}
+}
+
+func Test_Cuckoo_Invalid(t *testing.T) {
+	for _, option := range []minhash.CuckooOption{{FingerprintBits: 3}, {FingerprintBits: 33}, {BucketSize: 9}, {BucketSize: -1}} {
+		if _, err := minhash.NewCuckoo(100, option); err == nil {
+			t.Errorf("NewCuckoo with %+v succeeds", option)
+		}
+	}
+	if _, err := minhash.NewCuckoo(0); err == nil {
+		t.Error("NewCuckoo of no capacity succeeds")
+	}
+}
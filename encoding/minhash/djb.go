@@ -5,20 +5,116 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// djbInit is the initial hash value of the DJB hash algorithm.
+	djbInit = 5381
+)
+
 // DJB implements the classic DJB hash algorithm for 32 bits.
 func DJB(str []byte) uint32 {
-	var hash uint32 = 5381 // Common initialization value for DJB hash
+	return DJBSeed(0, str)
+}
+
+// DJBString is the same as DJB for string `str`, which hashes `str` without copying it.
+func DJBString(str string) uint32 {
+	return DJB(stringBytes(str))
+}
+
+// DJBSeed implements the classic DJB hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as DJB.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func DJBSeed(seed uint32, str []byte) uint32 {
+	var hash = djbInit ^ seed
 	for _, b := range str {
 		hash += (hash << 5) + uint32(b)
 	}
 	return hash
 }
 
+// DJBSeedString is the same as DJBSeed for string `str`, which hashes `str` without copying it.
+func DJBSeedString(seed uint32, str string) uint32 {
+	return DJBSeed(seed, stringBytes(str))
+}
+
 // DJB64 implements the classic DJB hash algorithm for 64 bits.
 func DJB64(str []byte) uint64 {
-	var hash uint64 = 5381 // Common initialization value for DJB hash
+	return DJB64Seed(0, str)
+}
+
+// DJB64String is the same as DJB64 for string `str`, which hashes `str` without copying it.
+func DJB64String(str string) uint64 {
+	return DJB64(stringBytes(str))
+}
+
+// DJB64Seed implements the classic DJB hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as DJB64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func DJB64Seed(seed uint64, str []byte) uint64 {
+	var hash = djbInit ^ seed
 	for _, b := range str {
 		hash += (hash << 5) + uint64(b)
 	}
 	return hash
 }
+
+// DJB64SeedString is the same as DJB64Seed for string `str`, which hashes `str` without copying it.
+func DJB64SeedString(seed uint64, str string) uint64 {
+	return DJB64Seed(seed, stringBytes(str))
+}
+
+type (
+	djb32 uint32
+	djb64 uint64
+)
+
+// NewDJB32 returns a new hash.Hash32 computing the DJB hash algorithm for 32 bits.
+// Its Sum32 result is identical to DJB for the same written data.
+func NewDJB32() hash.Hash32 {
+	var s djb32 = djbInit
+	return &s
+}
+
+// NewDJB64 returns a new hash.Hash64 computing the DJB hash algorithm for 64 bits.
+// Its Sum64 result is identical to DJB64 for the same written data.
+func NewDJB64() hash.Hash64 {
+	var s djb64 = djbInit
+	return &s
+}
+
+func (s *djb32) Reset() { *s = djbInit }
+func (s *djb64) Reset() { *s = djbInit }
+
+func (s *djb32) Sum32() uint32 { return uint32(*s) }
+func (s *djb64) Sum64() uint64 { return uint64(*s) }
+
+func (s *djb32) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash += (hash << 5) + djb32(b)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *djb64) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash += (hash << 5) + djb64(b)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *djb32) Size() int { return 4 }
+func (s *djb64) Size() int { return 8 }
+
+func (s *djb32) BlockSize() int { return 1 }
+func (s *djb64) BlockSize() int { return 1 }
+
+func (s *djb32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *djb64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
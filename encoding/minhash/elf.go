@@ -5,9 +5,26 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // ELF implements the classic ELF hash algorithm for 32 bits.
 func ELF(str []byte) uint32 {
-	var hash, x uint32
+	return ELFSeed(0, str)
+}
+
+// ELFString is the same as ELF for string `str`, which hashes `str` without copying it.
+func ELFString(str string) uint32 {
+	return ELF(stringBytes(str))
+}
+
+// ELFSeed implements the classic ELF hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as ELF.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func ELFSeed(seed uint32, str []byte) uint32 {
+	var hash, x = seed, uint32(0)
 	for _, b := range str {
 		hash = (hash << 4) + uint32(b)
 		if x = hash & 0xF0000000; x != 0 {
@@ -18,9 +35,26 @@ func ELF(str []byte) uint32 {
 	return hash
 }
 
+// ELFSeedString is the same as ELFSeed for string `str`, which hashes `str` without copying it.
+func ELFSeedString(seed uint32, str string) uint32 {
+	return ELFSeed(seed, stringBytes(str))
+}
+
 // ELF64 implements the classic ELF hash algorithm for 64 bits.
 func ELF64(str []byte) uint64 {
-	var hash, x uint64
+	return ELF64Seed(0, str)
+}
+
+// ELF64String is the same as ELF64 for string `str`, which hashes `str` without copying it.
+func ELF64String(str string) uint64 {
+	return ELF64(stringBytes(str))
+}
+
+// ELF64Seed implements the classic ELF hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as ELF64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func ELF64Seed(seed uint64, str []byte) uint64 {
+	var hash, x = seed, uint64(0)
 	for _, b := range str {
 		hash = (hash << 4) + uint64(b)
 		if x = hash & 0xF000000000000000; x != 0 {
@@ -30,3 +64,68 @@ func ELF64(str []byte) uint64 {
 	}
 	return hash
 }
+
+// ELF64SeedString is the same as ELF64Seed for string `str`, which hashes `str` without copying it.
+func ELF64SeedString(seed uint64, str string) uint64 {
+	return ELF64Seed(seed, stringBytes(str))
+}
+
+type (
+	elf32 uint32
+	elf64 uint64
+)
+
+// NewELF32 returns a new hash.Hash32 computing the ELF hash algorithm for 32 bits.
+// Its Sum32 result is identical to ELF for the same written data.
+func NewELF32() hash.Hash32 {
+	var s elf32
+	return &s
+}
+
+// NewELF64 returns a new hash.Hash64 computing the ELF hash algorithm for 64 bits.
+// Its Sum64 result is identical to ELF64 for the same written data.
+func NewELF64() hash.Hash64 {
+	var s elf64
+	return &s
+}
+
+func (s *elf32) Reset() { *s = 0 }
+func (s *elf64) Reset() { *s = 0 }
+
+func (s *elf32) Sum32() uint32 { return uint32(*s) }
+func (s *elf64) Sum64() uint64 { return uint64(*s) }
+
+func (s *elf32) Write(data []byte) (int, error) {
+	var hash, x = *s, elf32(0)
+	for _, b := range data {
+		hash = (hash << 4) + elf32(b)
+		if x = hash & 0xF0000000; x != 0 {
+			hash ^= x >> 24
+			hash &= ^x + 1
+		}
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *elf64) Write(data []byte) (int, error) {
+	var hash, x = *s, elf64(0)
+	for _, b := range data {
+		hash = (hash << 4) + elf64(b)
+		if x = hash & 0xF000000000000000; x != 0 {
+			hash ^= x >> 24
+			hash &= ^x + 1
+		}
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *elf32) Size() int { return 4 }
+func (s *elf64) Size() int { return 8 }
+
+func (s *elf32) BlockSize() int { return 1 }
+func (s *elf64) BlockSize() int { return 1 }
+
+func (s *elf32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *elf64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
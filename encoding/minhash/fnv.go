@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	fnvOffset32 uint32 = 2166136261
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime32  uint32 = 16777619
+	fnvPrime64  uint64 = 1099511628211
+)
+
+// FNV1a implements the FNV-1a hash algorithm for 32 bits.
+func FNV1a(str []byte) uint32 {
+	var hash = fnvOffset32
+	for _, b := range str {
+		hash ^= uint32(b)
+		hash *= fnvPrime32
+	}
+	return hash
+}
+
+// FNV1aString is the same as FNV1a for string `str`, which hashes `str` without copying it.
+func FNV1aString(str string) uint32 {
+	return FNV1a(stringBytes(str))
+}
+
+// FNV1a64 implements the FNV-1a hash algorithm for 64 bits.
+func FNV1a64(str []byte) uint64 {
+	var hash = fnvOffset64
+	for _, b := range str {
+		hash ^= uint64(b)
+		hash *= fnvPrime64
+	}
+	return hash
+}
+
+// FNV1a64String is the same as FNV1a64 for string `str`, which hashes `str` without copying it.
+func FNV1a64String(str string) uint64 {
+	return FNV1a64(stringBytes(str))
+}
+
+type (
+	fnv1a32 uint32
+	fnv1a64 uint64
+)
+
+// NewFNV1a32 returns a new hash.Hash32 computing the FNV-1a hash algorithm for 32 bits.
+// Its Sum32 result is identical to FNV1a for the same written data.
+func NewFNV1a32() hash.Hash32 {
+	var s = fnv1a32(fnvOffset32)
+	return &s
+}
+
+// NewFNV1a64 returns a new hash.Hash64 computing the FNV-1a hash algorithm for 64 bits.
+// Its Sum64 result is identical to FNV1a64 for the same written data.
+func NewFNV1a64() hash.Hash64 {
+	var s = fnv1a64(fnvOffset64)
+	return &s
+}
+
+func (s *fnv1a32) Reset() { *s = fnv1a32(fnvOffset32) }
+func (s *fnv1a64) Reset() { *s = fnv1a64(fnvOffset64) }
+
+func (s *fnv1a32) Sum32() uint32 { return uint32(*s) }
+func (s *fnv1a64) Sum64() uint64 { return uint64(*s) }
+
+func (s *fnv1a32) Write(data []byte) (int, error) {
+	hash := uint32(*s)
+	for _, b := range data {
+		hash ^= uint32(b)
+		hash *= fnvPrime32
+	}
+	*s = fnv1a32(hash)
+	return len(data), nil
+}
+
+func (s *fnv1a64) Write(data []byte) (int, error) {
+	hash := uint64(*s)
+	for _, b := range data {
+		hash ^= uint64(b)
+		hash *= fnvPrime64
+	}
+	*s = fnv1a64(hash)
+	return len(data), nil
+}
+
+func (s *fnv1a32) Size() int { return 4 }
+func (s *fnv1a64) Size() int { return 8 }
+
+func (s *fnv1a32) BlockSize() int { return 1 }
+func (s *fnv1a64) BlockSize() int { return 1 }
+
+func (s *fnv1a32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *fnv1a64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_FNV1a_Vectors(t *testing.T) {
+	// The vectors of the test suite of the FNV reference implementation.
+	for _, v := range []struct {
+		str string
+		h32 uint32
+		h64 uint64
+	}{
+		{"", 0x811c9dc5, 0xcbf29ce484222325},
+		{"a", 0xe40c292c, 0xaf63dc4c8601ec8c},
+		{"foobar", 0xbf9cf968, 0x85944171f73967e8},
+	} {
+		if got := minhash.FNV1a([]byte(v.str)); got != v.h32 {
+			t.Errorf("FNV1a(%q) = %#x, want %#x", v.str, got, v.h32)
+		}
+		if got := minhash.FNV1a64([]byte(v.str)); got != v.h64 {
+			t.Errorf("FNV1a64(%q) = %#x, want %#x", v.str, got, v.h64)
+		}
+		h32 := minhash.NewFNV1a32()
+		h32.Write([]byte(v.str))
+		if got := h32.Sum32(); got != v.h32 {
+			t.Errorf("NewFNV1a32 of %q = %#x, want %#x", v.str, got, v.h32)
+		}
+		h64 := minhash.NewFNV1a64()
+		h64.Write([]byte(v.str))
+		if got := h64.Sum64(); got != v.h64 {
+			t.Errorf("NewFNV1a64 of %q = %#x, want %#x", v.str, got, v.h64)
+		}
+	}
+}
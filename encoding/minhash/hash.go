@@ -3,5 +3,197 @@
 // Use of this source code is governed by an MIT-style license
 // that can be found in the LICENSE file.
 
-// Package minhash provides some classic hash functions(uint32/uint64) in go.
+// Package minhash provides some classic and modern hash functions(uint32/uint64/uint128) in go.
 package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// Hasher is the interface for a named hash algorithm, which can be registered
+// and looked up by its name, eg: picking an algorithm from configuration.
+type Hasher interface {
+	// Name returns the unique name of the algorithm, eg: "bkdr64".
+	Name() string
+
+	// Size returns the number of bytes of the digest, 4 for 32 bits algorithms and 8 for 64 bits.
+	Size() int
+
+	// Sum64 returns the digest of `data`. Digests of 32 bits algorithms are zero-extended.
+	Sum64(data []byte) uint64
+
+	// New returns a new streaming hash.Hash computing the same digest as Sum64.
+	New() hash.Hash
+}
+
+// Uint128 is a 128 bits digest, whose value is Hi<<64 | Lo.
+type Uint128 struct {
+	Hi uint64 // High 64 bits.
+	Lo uint64 // Low 64 bits.
+}
+
+// Bytes returns the digest in big-endian byte order.
+func (u Uint128) Bytes() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], u.Hi)
+	binary.BigEndian.PutUint64(b[8:], u.Lo)
+	return b
+}
+
+// stringBytes returns the bytes of `str` without copying it.
+// The bytes must not be modified, which is guaranteed as the hash functions only read their input.
+func stringBytes(str string) []byte {
+	return unsafe.Slice(unsafe.StringData(str), len(str))
+}
+
+// hasher32 implements Hasher for 32 bits algorithms.
+type hasher32 struct {
+	name    string
+	sumFunc func(data []byte) uint32
+	newFunc func() hash.Hash32
+}
+
+// hasher64 implements Hasher for 64 bits algorithms.
+type hasher64 struct {
+	name    string
+	sumFunc func(data []byte) uint64
+	newFunc func() hash.Hash64
+}
+
+// goldenRatio64 is the 64 bits golden ratio, whose multiples are well distributed seeds and constants.
+const goldenRatio64 = 0x9e3779b97f4a7c15
+
+var (
+	// defaultHasher is the Hasher used by the components of this package when no Hasher is specified.
+	defaultHasher = NewHasher64("xxh64", XXH64, NewXXH64)
+	// registryMu protects registry for concurrent Register and Lookup.
+	registryMu sync.RWMutex
+	// registry maps lowercase algorithm names to their Hasher.
+	registry = make(map[string]Hasher)
+)
+
+func init() {
+	for _, h := range []Hasher{
+		NewHasher32("ap32", AP, NewAP32),
+		NewHasher64("ap64", AP64, NewAP64),
+		NewHasher32("bkdr32", BKDR, NewBKDR32),
+		NewHasher64("bkdr64", BKDR64, NewBKDR64),
+		NewHasher32("djb32", DJB, NewDJB32),
+		NewHasher64("djb64", DJB64, NewDJB64),
+		NewHasher32("elf32", ELF, NewELF32),
+		NewHasher64("elf64", ELF64, NewELF64),
+		NewHasher32("js32", JS, NewJS32),
+		NewHasher64("js64", JS64, NewJS64),
+		NewHasher32("pjw32", PJW, NewPJW32),
+		NewHasher64("pjw64", PJW64, NewPJW64),
+		NewHasher32("rs32", RS, NewRS32),
+		NewHasher64("rs64", RS64, NewRS64),
+		NewHasher32("sdbm32", SDBM, NewSDBM32),
+		NewHasher64("sdbm64", SDBM64, NewSDBM64),
+		NewHasher32("fnv1a32", FNV1a, NewFNV1a32),
+		NewHasher64("fnv1a64", FNV1a64, NewFNV1a64),
+		NewHasher32("murmur3", Murmur3, NewMurmur3),
+		defaultHasher,
+		NewHasher64("xxh3", XXH3, NewXXH3),
+		NewHasher32("crc32c", CRC32C, NewCRC32C),
+		NewHasher64("crc64ecma", CRC64, NewCRC64),
+		NewHasher32("adler32", Adler32, NewAdler32),
+	} {
+		registry[h.Name()] = h
+	}
+}
+
+// NewHasher32 creates and returns a Hasher for a 32 bits algorithm,
+// using `sumFunc` for one-shot hashing and `newFunc` for streaming hashing.
+func NewHasher32(name string, sumFunc func(data []byte) uint32, newFunc func() hash.Hash32) Hasher {
+	return &hasher32{
+		name:    name,
+		sumFunc: sumFunc,
+		newFunc: newFunc,
+	}
+}
+
+// NewHasher64 creates and returns a Hasher for a 64 bits algorithm,
+// using `sumFunc` for one-shot hashing and `newFunc` for streaming hashing.
+func NewHasher64(name string, sumFunc func(data []byte) uint64, newFunc func() hash.Hash64) Hasher {
+	return &hasher64{
+		name:    name,
+		sumFunc: sumFunc,
+		newFunc: newFunc,
+	}
+}
+
+// Register adds `hasher` to the registry, so it can be looked up by its name.
+// The name is case-insensitive, and it returns an error if the name is empty or already registered.
+func Register(hasher Hasher) error {
+	if hasher == nil {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "hasher should not be nil")
+	}
+	name := strings.ToLower(hasher.Name())
+	if name == "" {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "hasher name should not be empty")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		return minerror.NewCodef(mincode.CodeInvalidOperation, `hasher "%s" is already registered`, name)
+	}
+	registry[name] = hasher
+	return nil
+}
+
+// Lookup returns the registered Hasher by case-insensitive `name`.
+// It returns an error if no hasher is registered under `name`.
+func Lookup(name string) (Hasher, error) {
+	registryMu.RLock()
+	h, ok := registry[strings.ToLower(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, minerror.NewCodef(mincode.CodeNotFound, `hasher "%s" is not registered`, name)
+	}
+	return h, nil
+}
+
+// List returns the names of all registered hashers in ascending order.
+func List() []string {
+	registryMu.RLock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the name of the algorithm.
+func (h *hasher32) Name() string { return h.name }
+
+// Size returns the number of bytes of the digest.
+func (h *hasher32) Size() int { return 4 }
+
+// Sum64 returns the zero-extended 32 bits digest of `data`.
+func (h *hasher32) Sum64(data []byte) uint64 { return uint64(h.sumFunc(data)) }
+
+// New returns a new streaming hash.Hash of the algorithm.
+func (h *hasher32) New() hash.Hash { return h.newFunc() }
+
+// Name returns the name of the algorithm.
+func (h *hasher64) Name() string { return h.name }
+
+// Size returns the number of bytes of the digest.
+func (h *hasher64) Size() int { return 8 }
+
+// Sum64 returns the 64 bits digest of `data`.
+func (h *hasher64) Sum64(data []byte) uint64 { return h.sumFunc(data) }
+
+// New returns a new streaming hash.Hash of the algorithm.
+func (h *hasher64) New() hash.Hash { return h.newFunc() }
@@ -0,0 +1,112 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"bytes"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// digestUint64 returns the big-endian integer of `digest`.
+func digestUint64(digest []byte) uint64 {
+	var u uint64
+	for _, b := range digest {
+		u = u<<8 | uint64(b)
+	}
+	return u
+}
+
+func Test_Hasher_Streaming(t *testing.T) {
+	data := chunkData(10000, 9)
+	for _, name := range minhash.List() {
+		hasher, err := minhash.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range []int{0, 1, 3, 4, 7, 8, 15, 16, 17, 31, 32, 33, 63, 64, 65, 127, 128, 129, 240, 241, 1000, 10000} {
+			want := hasher.Sum64(data[:n])
+			// The data is written in pieces of different sizes, which covers the block boundaries of the algorithms.
+			for _, piece := range []int{1, 3, 5, 16, 32, 100, n + 1} {
+				h := hasher.New()
+				for i := 0; i < n; i += piece {
+					if written, err := h.Write(data[i:min(i+piece, n)]); err != nil || written != min(piece, n-i) {
+						t.Fatalf("%s: Write = %d, %v", name, written, err)
+					}
+				}
+				digest := h.Sum([]byte("prefix"))
+				if !bytes.HasPrefix(digest, []byte("prefix")) || len(digest) != len("prefix")+hasher.Size() {
+					t.Fatalf("%s: Sum does not append the digest of %d bytes", name, hasher.Size())
+				}
+				if got := digestUint64(digest[len("prefix"):]); got != want {
+					t.Fatalf("%s: streaming hash of %d bytes in pieces of %d = %#x, want %#x", name, n, piece, got, want)
+				}
+				// Sum does not change the state, and Reset restores the initial state.
+				if got := digestUint64(h.Sum(nil)); got != want {
+					t.Fatalf("%s: second Sum of %d bytes = %#x, want %#x", name, n, got, want)
+				}
+				h.Reset()
+				h.Write(data[:n])
+				if got := digestUint64(h.Sum(nil)); got != want {
+					t.Fatalf("%s: hash of %d bytes after Reset = %#x, want %#x", name, n, got, want)
+				}
+			}
+		}
+		if h := hasher.New(); h.Size() != hasher.Size() || h.BlockSize() <= 0 {
+			t.Errorf("%s: Size = %d, BlockSize = %d", name, h.Size(), h.BlockSize())
+		}
+	}
+}
+
+// registerRuns is the number of runs of Test_Register, which makes the registered names unique across runs.
+var registerRuns int
+
+func Test_Register(t *testing.T) {
+	registerRuns++
+	name := "Test-FNV1-" + strconv.Itoa(registerRuns)
+	custom := minhash.NewHasher32(name, func(data []byte) uint32 {
+		h := fnv.New32()
+		h.Write(data)
+		return h.Sum32()
+	}, fnv.New32)
+	if err := minhash.Register(custom); err != nil {
+		t.Fatal(err)
+	}
+	// The names are case-insensitive.
+	for _, name := range []string{strings.ToLower(name), strings.ToUpper(name)} {
+		if hasher, err := minhash.Lookup(name); err != nil || hasher != custom {
+			t.Fatalf("Lookup(%s) = %v, %v", name, hasher, err)
+		}
+	}
+	var listed bool
+	for _, listedName := range minhash.List() {
+		listed = listed || listedName == strings.ToLower(name)
+	}
+	if !listed {
+		t.Fatal("registered hasher is not listed")
+	}
+	if err := minhash.Register(custom); minerror.Code(err) != mincode.CodeInvalidOperation {
+		t.Errorf("Register of registered name = %v", err)
+	}
+	if err := minhash.Register(minhash.NewHasher64("XXH64", minhash.XXH64, nil)); err == nil {
+		t.Error("Register of builtin name succeeds")
+	}
+	if err := minhash.Register(minhash.NewHasher32("", minhash.FNV1a, nil)); minerror.Code(err) != mincode.CodeInvalidParameter {
+		t.Errorf("Register of empty name = %v", err)
+	}
+	if err := minhash.Register(nil); minerror.Code(err) != mincode.CodeInvalidParameter {
+		t.Errorf("Register of nil = %v", err)
+	}
+	if _, err := minhash.Lookup("missing"); minerror.Code(err) != mincode.CodeNotFound {
+		t.Errorf("Lookup of missing name = %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// hllMagic is the magic of the binary format of HyperLogLog.
+	hllMagic = "MHLL"
+	// hllPrecisionMin is the minimum precision of HyperLogLog.
+	hllPrecisionMin = 4
+	// hllPrecisionMax is the maximum precision of HyperLogLog.
+	hllPrecisionMax = 18
+	// hllSparsePrecision is the precision of the sparse representation.
+	hllSparsePrecision = 25
+	// hllModeSparse marks the sparse representation in binary format.
+	hllModeSparse = 0
+	// hllModeDense marks the dense representation in binary format.
+	hllModeDense = 1
+)
+
+// HyperLogLog is a HyperLogLog++ cardinality estimator, which estimates the number of distinct items
+// with a standard error of about 1.04/sqrt(2^precision).
+// Small sets are kept in a sparse representation with higher precision, which is converted to
+// the dense registers once it grows larger than them.
+// It is not safe for concurrent use.
+type HyperLogLog struct {
+	hasher    Hasher   // 64 bits hash algorithm for items.
+	p         uint8    // Precision, the number of registers is 2^p.
+	sparse    []uint32 // Sorted sparse entries, each entry is index<<6 | rank with precision 25.
+	pending   []uint32 // Unsorted sparse entries waiting to be merged into sparse.
+	registers []uint8  // Dense registers, nil in sparse representation.
+}
+
+// NewHyperLogLog creates and returns an empty HyperLogLog with `precision` in range [4, 18].
+// The items are hashed by optional `hasher`, which is xxh64 in default and must be a 64 bits algorithm.
+func NewHyperLogLog(precision uint8, hasher ...Hasher) (*HyperLogLog, error) {
+	if precision < hllPrecisionMin || precision > hllPrecisionMax {
+		return nil, minerror.NewCodef(
+			mincode.CodeInvalidParameter, "hyperloglog precision %d out of range [%d, %d]",
+			precision, hllPrecisionMin, hllPrecisionMax,
+		)
+	}
+	h := &HyperLogLog{
+		hasher: defaultHasher,
+		p:      precision,
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		if hasher[0].Size() != 8 {
+			return nil, minerror.NewCodef(
+				mincode.CodeInvalidParameter, `hyperloglog requires a 64 bits hasher, but "%s" is not`, hasher[0].Name(),
+			)
+		}
+		h.hasher = hasher[0]
+	}
+	return h, nil
+}
+
+// Add adds `data` to the estimator.
+func (h *HyperLogLog) Add(data []byte) {
+	h.AddHash(h.hasher.Sum64(data))
+}
+
+// AddHash adds an item by its 64 bits `hash`, which should be produced by the same hasher
+// as the estimator for the items to be counted consistently.
+func (h *HyperLogLog) AddHash(hash uint64) {
+	if h.registers != nil {
+		index, rank := hllDense(hash, h.p)
+		h.registers[index] = max(h.registers[index], rank)
+		return
+	}
+	h.pending = append(h.pending, hllSparse(hash))
+	if len(h.pending) >= h.sparseMax()/4 {
+		h.mergePending()
+	}
+}
+
+// Count returns the estimated number of distinct items added to the estimator.
+func (h *HyperLogLog) Count() uint64 {
+	h.mergePending()
+	if h.registers == nil {
+		// Linear counting over the sparse registers, which is accurate for small sets.
+		m := float64(uint64(1) << hllSparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+	return uint64(math.Round(hllEstimate(h.registers, h.p)))
+}
+
+// Merge merges `other` into the estimator, then the estimator counts the items of both estimators.
+// It is used to combine estimators of different shards or instances.
+// It returns an error if the estimators do not have the same precision and hasher.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil || h.p != other.p || h.hasher.Name() != other.hasher.Name() {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "incompatible hyperloglog estimators")
+	}
+	other.mergePending()
+	if h.registers == nil && other.registers == nil {
+		h.pending = append(h.pending, other.sparse...)
+		h.mergePending()
+		return nil
+	}
+	h.toDense()
+	if other.registers != nil {
+		for i, rank := range other.registers {
+			h.registers[i] = max(h.registers[i], rank)
+		}
+		return nil
+	}
+	for _, entry := range other.sparse {
+		index, rank := hllSparseToDense(entry, h.p)
+		h.registers[index] = max(h.registers[index], rank)
+	}
+	return nil
+}
+
+// Precision returns the precision of the estimator.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.p
+}
+
+// Reset removes all items from the estimator and restores the sparse representation.
+func (h *HyperLogLog) Reset() {
+	h.sparse, h.pending, h.registers = nil, nil, nil
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The sparse entries are delta encoded, so the format stays compact for small sets.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	h.mergePending()
+	buf := appendHeader(nil, hllMagic, h.hasher)
+	buf = append(buf, h.p)
+	if h.registers != nil {
+		buf = append(buf, hllModeDense)
+		return append(buf, h.registers...), nil
+	}
+	buf = append(buf, hllModeSparse)
+	buf = binary.AppendUvarint(buf, uint64(len(h.sparse)))
+	var last uint32
+	for _, entry := range h.sparse {
+		buf = binary.AppendUvarint(buf, uint64(entry-last))
+		last = entry
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, hllMagic)
+	if err != nil {
+		return err
+	}
+	var (
+		header = r.bytes(2)
+		result *HyperLogLog
+	)
+	if r.err != nil {
+		return r.err
+	}
+	if result, err = NewHyperLogLog(header[0], hasher); err != nil {
+		return err
+	}
+	switch header[1] {
+	case hllModeDense:
+		result.registers = append([]uint8(nil), r.bytes(uint64(1)<<result.p)...)
+		for _, rank := range result.registers {
+			if int(rank) > 65-int(result.p) {
+				return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for hyperloglog")
+			}
+		}
+
+	case hllModeSparse:
+		count := r.uvarint()
+		if count > uint64(len(r.data)) {
+			return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for hyperloglog")
+		}
+		result.sparse = make([]uint32, 0, count)
+		var last uint64
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			last += r.uvarint()
+			if last >= 1<<(hllSparsePrecision+6) || last&63 > 64-hllSparsePrecision+1 {
+				return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for hyperloglog")
+			}
+			result.sparse = append(result.sparse, uint32(last))
+		}
+
+	default:
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for hyperloglog")
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	*h = *result
+	return nil
+}
+
+// sparseMax returns the maximum number of sparse entries before converting to dense registers,
+// at which the sparse entries take as much memory as the dense registers.
+func (h *HyperLogLog) sparseMax() int {
+	return max((1<<h.p)/4, 16)
+}
+
+// mergePending sorts and merges the pending entries into the sparse entries,
+// keeping the maximum rank for each index, and converts to dense registers if needed.
+func (h *HyperLogLog) mergePending() {
+	if len(h.pending) == 0 {
+		return
+	}
+	entries := append(h.sparse, h.pending...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i] < entries[j]
+	})
+	// Entries of the same index are adjacent with ascending ranks, keep the last one.
+	merged := entries[:0]
+	for i, entry := range entries {
+		if i+1 < len(entries) && entries[i+1]>>6 == entry>>6 {
+			continue
+		}
+		merged = append(merged, entry)
+	}
+	h.sparse, h.pending = merged, h.pending[:0]
+	if len(h.sparse) > h.sparseMax() {
+		h.toDense()
+	}
+}
+
+// toDense converts the sparse representation to dense registers.
+func (h *HyperLogLog) toDense() {
+	if h.registers != nil {
+		return
+	}
+	h.mergePending()
+	if h.registers != nil {
+		return
+	}
+	h.registers = make([]uint8, 1<<h.p)
+	for _, entry := range h.sparse {
+		index, rank := hllSparseToDense(entry, h.p)
+		h.registers[index] = max(h.registers[index], rank)
+	}
+	h.sparse, h.pending = nil, nil
+}
+
+// hllDense returns the register index and rank of `hash` with precision `p`.
+func hllDense(hash uint64, p uint8) (index uint32, rank uint8) {
+	index = uint32(hash >> (64 - p))
+	rank = uint8(min(bits.LeadingZeros64(hash<<p), 64-int(p)) + 1)
+	return index, rank
+}
+
+// hllSparse returns the sparse entry of `hash`, which is index<<6 | rank with precision 25.
+func hllSparse(hash uint64) uint32 {
+	index, rank := hllDense(hash, hllSparsePrecision)
+	return index<<6 | uint32(rank)
+}
+
+// hllSparseToDense converts a sparse `entry` to the register index and rank with precision `p`.
+func hllSparseToDense(entry uint32, p uint8) (index uint32, rank uint8) {
+	var (
+		sparseIndex = entry >> 6
+		extraBits   = hllSparsePrecision - p
+		extra       = sparseIndex & (1<<extraBits - 1)
+	)
+	index = sparseIndex >> extraBits
+	if extra != 0 {
+		return index, uint8(bits.LeadingZeros32(extra<<(32-extraBits))) + 1
+	}
+	return index, uint8(entry&63) + extraBits
+}
+
+// hllEstimate estimates the cardinality from dense `registers` with precision `p`,
+// using the improved estimator of Otmar Ertl, which needs no empirical bias correction.
+func hllEstimate(registers []uint8, p uint8) float64 {
+	var (
+		q      = 64 - int(p)
+		m      = float64(len(registers))
+		counts = make([]float64, q+2)
+	)
+	for _, rank := range registers {
+		counts[rank]++
+	}
+	z := m * hllTau(1-counts[q+1]/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + counts[k])
+	}
+	z += m * hllSigma(counts[0]/m)
+	return m * m / (2 * math.Ln2 * z)
+}
+
+// hllSigma is the sigma function of the improved estimator.
+func hllSigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	var y, z = 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+// hllTau is the tau function of the improved estimator.
+func hllTau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	var y, z = 1.0, 1 - x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// addRange adds the items numbered from `from` to `to`, exclusive, to `h`.
+func addRange(h *minhash.HyperLogLog, from, to int) {
+	for i := from; i < to; i++ {
+		h.Add([]byte("item:" + strconv.Itoa(i)))
+	}
+}
+
+// checkCount checks whether the count of `h` is within `tolerance` relative error of `want`.
+func checkCount(t *testing.T, name string, h *minhash.HyperLogLog, want int, tolerance float64) {
+	t.Helper()
+	if got := h.Count(); math.Abs(float64(got)-float64(want)) > tolerance*float64(want) {
+		t.Errorf("%s: Count = %d, want %d within %.1f%%", name, got, want, 100*tolerance)
+	}
+}
+
+func Test_HyperLogLog_ErrorBound(t *testing.T) {
+	for _, p := range []uint8{10, 14} {
+		h, err := minhash.NewHyperLogLog(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The standard error is 1.04/sqrt(2^p), 4 times of which is allowed.
+		var (
+			stdErr = 1.04 / math.Sqrt(float64(uint64(1)<<p))
+			added  int
+		)
+		for _, n := range []int{10, 100, 1000, 10000, 100000, 500000} {
+			addRange(h, added, n)
+			added = n
+			tolerance := 4 * stdErr
+			if n < 1<<p/4 {
+				// The sparse representation is nearly exact for the sets smaller than a quarter of the registers.
+				tolerance = 0.01
+			}
+			checkCount(t, "p"+strconv.Itoa(int(p))+" n"+strconv.Itoa(n), h, n, tolerance)
+		}
+		// Duplicated items are not counted.
+		addRange(h, 0, 1000)
+		checkCount(t, "duplicated", h, added, 4*stdErr)
+	}
+	if h, _ := minhash.NewHyperLogLog(4); h.Count() != 0 {
+		t.Fatal("Count of empty estimator is not 0")
+	}
+}
+
+func Test_HyperLogLog_Merge(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		n1, n2     int
+		overlapped int
+	}{
+		{"sparse", 100, 200, 50},
+		{"sparse into dense", 50000, 300, 100},
+		{"dense into sparse", 300, 50000, 100},
+		{"dense", 40000, 60000, 20000},
+	} {
+		var (
+			h1, _ = minhash.NewHyperLogLog(14)
+			h2, _ = minhash.NewHyperLogLog(14)
+		)
+		addRange(h1, 0, c.n1)
+		addRange(h2, c.n1-c.overlapped, c.n1-c.overlapped+c.n2)
+		if err := h1.Merge(h2); err != nil {
+			t.Fatal(err)
+		}
+		checkCount(t, c.name, h1, c.n1+c.n2-c.overlapped, 0.04)
+	}
+	var (
+		h, _     = minhash.NewHyperLogLog(14)
+		other, _ = minhash.NewHyperLogLog(12)
+		fnv, _   = minhash.Lookup("fnv1a64")
+		fnvH, _  = minhash.NewHyperLogLog(14, fnv)
+	)
+	if h.Merge(other) == nil || h.Merge(fnvH) == nil || h.Merge(nil) == nil {
+		t.Fatal("Merge of incompatible estimator succeeds")
+	}
+}
+
+func Test_HyperLogLog_Marshal(t *testing.T) {
+	for _, n := range []int{0, 500, 100000} {
+		h, _ := minhash.NewHyperLogLog(12)
+		addRange(h, 0, n)
+		data, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result minhash.HyperLogLog
+		if err = result.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%d: UnmarshalBinary failed: %v", n, err)
+		}
+		if result.Count() != h.Count() || result.Precision() != 12 {
+			t.Fatalf("%d: unmarshaled Count = %d, want %d", n, result.Count(), h.Count())
+		}
+		// The unmarshaled estimator keeps counting.
+		addRange(&result, n, n+1000)
+		addRange(h, n, n+1000)
+		if result.Count() != h.Count() {
+			t.Fatalf("%d: Count after Add = %d, want %d", n, result.Count(), h.Count())
+		}
+		if result.UnmarshalBinary(data[:len(data)-1]) == nil {
+			t.Fatalf("%d: UnmarshalBinary of truncated data succeeds", n)
+		}
+	}
+}
+
+func Test_HyperLogLog_Params(t *testing.T) {
+	for _, p := range []uint8{0, 3, 19} {
+		if _, err := minhash.NewHyperLogLog(p); err == nil {
+			t.Errorf("NewHyperLogLog(%d) succeeds", p)
+		}
+	}
+	fnv32, _ := minhash.Lookup("fnv1a32")
+	if _, err := minhash.NewHyperLogLog(14, fnv32); err == nil {
+		t.Error("NewHyperLogLog with 32 bits hasher succeeds")
+	}
+	h, _ := minhash.NewHyperLogLog(14)
+	addRange(h, 0, 100000)
+	h.Reset()
+	if h.Count() != 0 {
+		t.Error("Reset does not remove items")
+	}
+	h.AddHash(minhash.XXH64([]byte("item:1")))
+	h.Add([]byte("item:1"))
+	if h.Count() != 1 {
+		t.Errorf("Count of AddHash and Add of the same item = %d", h.Count())
+	}
+}
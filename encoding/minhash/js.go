@@ -5,20 +5,116 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// jsInit is the initial hash value of the JS hash algorithm.
+	jsInit = 1315423911
+)
+
 // JS implements the classic JS hash algorithm for 32 bits.
 func JS(str []byte) uint32 {
-	var hash uint32 = 1315423911 // Initial value used in JS hash algorithm
+	return JSSeed(0, str)
+}
+
+// JSString is the same as JS for string `str`, which hashes `str` without copying it.
+func JSString(str string) uint32 {
+	return JS(stringBytes(str))
+}
+
+// JSSeed implements the classic JS hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as JS.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func JSSeed(seed uint32, str []byte) uint32 {
+	var hash = jsInit ^ seed
 	for _, b := range str {
 		hash ^= (hash << 5) + uint32(b) + (hash >> 2)
 	}
 	return hash
 }
 
+// JSSeedString is the same as JSSeed for string `str`, which hashes `str` without copying it.
+func JSSeedString(seed uint32, str string) uint32 {
+	return JSSeed(seed, stringBytes(str))
+}
+
 // JS64 implements the classic JS hash algorithm for 64 bits.
 func JS64(str []byte) uint64 {
-	var hash uint64 = 1315423911 // Initial value used in JS hash algorithm
+	return JS64Seed(0, str)
+}
+
+// JS64String is the same as JS64 for string `str`, which hashes `str` without copying it.
+func JS64String(str string) uint64 {
+	return JS64(stringBytes(str))
+}
+
+// JS64Seed implements the classic JS hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as JS64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func JS64Seed(seed uint64, str []byte) uint64 {
+	var hash = jsInit ^ seed
 	for _, b := range str {
 		hash ^= (hash << 5) + uint64(b) + (hash >> 2)
 	}
 	return hash
 }
+
+// JS64SeedString is the same as JS64Seed for string `str`, which hashes `str` without copying it.
+func JS64SeedString(seed uint64, str string) uint64 {
+	return JS64Seed(seed, stringBytes(str))
+}
+
+type (
+	js32 uint32
+	js64 uint64
+)
+
+// NewJS32 returns a new hash.Hash32 computing the JS hash algorithm for 32 bits.
+// Its Sum32 result is identical to JS for the same written data.
+func NewJS32() hash.Hash32 {
+	var s js32 = jsInit
+	return &s
+}
+
+// NewJS64 returns a new hash.Hash64 computing the JS hash algorithm for 64 bits.
+// Its Sum64 result is identical to JS64 for the same written data.
+func NewJS64() hash.Hash64 {
+	var s js64 = jsInit
+	return &s
+}
+
+func (s *js32) Reset() { *s = jsInit }
+func (s *js64) Reset() { *s = jsInit }
+
+func (s *js32) Sum32() uint32 { return uint32(*s) }
+func (s *js64) Sum64() uint64 { return uint64(*s) }
+
+func (s *js32) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash ^= (hash << 5) + js32(b) + (hash >> 2)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *js64) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash ^= (hash << 5) + js64(b) + (hash >> 2)
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *js32) Size() int { return 4 }
+func (s *js64) Size() int { return 8 }
+
+func (s *js32) BlockSize() int { return 1 }
+func (s *js64) BlockSize() int { return 1 }
+
+func (s *js32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *js64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
@@ -0,0 +1,23 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+// Jump implements Google's Jump Consistent Hash, which maps `key` to a bucket in range [0, buckets).
+// The key is usually produced by a 64 bits hash function of this package, eg: XXH64.
+// When the number of buckets grows from N to N+1, only 1/(N+1) of the keys move to the new bucket.
+// It returns -1 if `buckets` is not positive.
+func Jump(key uint64, buckets int) int {
+	if buckets <= 0 {
+		return -1
+	}
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Jump_Vectors(t *testing.T) {
+	// The vectors are computed by the reference implementation in the paper.
+	for _, v := range []struct {
+		key     uint64
+		buckets int
+		want    int
+	}{
+		{1, 1, 0},
+		{42, 57, 43},
+		{0xdead10cc, 1, 0},
+		{0xdead10cc, 666, 361},
+		{256, 1024, 520},
+		{0, 100, 0},
+		{0xffffffffffffffff, 1 << 20, 589430},
+		{123456789, 10, 7},
+		{1, 0, -1},
+		{1, -5, -1},
+	} {
+		if got := minhash.Jump(v.key, v.buckets); got != v.want {
+			t.Errorf("Jump(%#x, %d) = %d, want %d", v.key, v.buckets, got, v.want)
+		}
+	}
+}
+
+func Test_Jump_Movement(t *testing.T) {
+	const buckets = 32
+	counts := make([]int, buckets)
+	for i, key := range benchmarkKeys(64000) {
+		var (
+			hash = minhash.XXH64String(key)
+			prev = minhash.Jump(hash, 1)
+		)
+		// Growing the buckets only moves keys to the new bucket.
+		for n := 2; n <= buckets; n++ {
+			b := minhash.Jump(hash, n)
+			if b != prev && b != n-1 {
+				t.Fatalf("key %d moves from %d to %d with %d buckets", i, prev, b, n)
+			}
+			prev = b
+		}
+		counts[prev]++
+	}
+	for b, count := range counts {
+		if count < 1600 || count > 2400 {
+			t.Errorf("bucket %d has %d keys, want about 2000", b, count)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// LSH is a locality-sensitive hashing index of MinHash signatures for near-duplicate lookup.
+// Each signature is split into bands of rows, and signatures sharing any identical band
+// are candidates of each other. Sets with Jaccard similarity s become candidates with
+// probability 1-(1-s^rows)^bands, whose threshold is about (1/bands)^(1/rows).
+// It is safe for concurrent use.
+type LSH struct {
+	mu         sync.RWMutex
+	bands      int
+	rows       int
+	buckets    []map[uint64][]string // Keys of each band by the hash of the band.
+	signatures map[string]Signature  // Signatures by key.
+}
+
+// NewLSH creates and returns an LSH index of `bands` bands with `rows` rows each.
+// The indexed signatures must have at least bands*rows values.
+func NewLSH(bands, rows int) (*LSH, error) {
+	if bands <= 0 || rows <= 0 {
+		return nil, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid lsh size %dx%d", bands, rows)
+	}
+	l := &LSH{
+		bands:      bands,
+		rows:       rows,
+		buckets:    make([]map[uint64][]string, bands),
+		signatures: make(map[string]Signature),
+	}
+	for i := range l.buckets {
+		l.buckets[i] = make(map[uint64][]string)
+	}
+	return l, nil
+}
+
+// Add indexes `signature` with `key`, replacing the signature of `key` if it is already indexed.
+// It returns an error if the signature is too short for the index.
+func (l *LSH) Add(key string, signature Signature) error {
+	if err := l.checkSignature(signature); err != nil {
+		return err
+	}
+	signature = append(Signature(nil), signature...)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remove(key)
+	for band := range l.buckets {
+		hash := l.bandHash(signature, band)
+		l.buckets[band][hash] = append(l.buckets[band][hash], key)
+	}
+	l.signatures[key] = signature
+	return nil
+}
+
+// Remove removes `key` from the index.
+func (l *LSH) Remove(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remove(key)
+}
+
+// Query returns the sorted keys of the candidates sharing any band with `signature`.
+// If the optional `threshold` is given, only the candidates whose estimated Jaccard similarity
+// with `signature` is at least `threshold` are returned.
+// It returns an error if the signature is too short for the index.
+func (l *LSH) Query(signature Signature, threshold ...float64) ([]string, error) {
+	if err := l.checkSignature(signature); err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var (
+		keys = make([]string, 0)
+		seen = make(map[string]struct{})
+	)
+	for band := range l.buckets {
+		for _, key := range l.buckets[band][l.bandHash(signature, band)] {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if len(threshold) > 0 && l.signatures[key].Jaccard(signature) < threshold[0] {
+				continue
+			}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Signature returns a copy of the indexed signature of `key` and whether `key` is indexed.
+func (l *LSH) Signature(key string) (Signature, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	signature, ok := l.signatures[key]
+	if !ok {
+		return nil, false
+	}
+	return append(Signature(nil), signature...), true
+}
+
+// Len returns the number of indexed keys.
+func (l *LSH) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.signatures)
+}
+
+// checkSignature checks whether `signature` is long enough for the index.
+func (l *LSH) checkSignature(signature Signature) error {
+	if len(signature) < l.bands*l.rows {
+		return minerror.NewCodef(
+			mincode.CodeInvalidParameter, "signature size %d is less than lsh size %dx%d",
+			len(signature), l.bands, l.rows,
+		)
+	}
+	return nil
+}
+
+// remove removes `key` from the index without locking.
+func (l *LSH) remove(key string) {
+	signature, ok := l.signatures[key]
+	if !ok {
+		return
+	}
+	for band := range l.buckets {
+		var (
+			hash = l.bandHash(signature, band)
+			keys = l.buckets[band][hash]
+		)
+		for i, k := range keys {
+			if k == key {
+				keys = append(keys[:i], keys[i+1:]...)
+				break
+			}
+		}
+		if len(keys) == 0 {
+			delete(l.buckets[band], hash)
+		} else {
+			l.buckets[band][hash] = keys
+		}
+	}
+	delete(l.signatures, key)
+}
+
+// bandHash returns the hash of the rows of `band` in `signature`.
+func (l *LSH) bandHash(signature Signature, band int) uint64 {
+	hash := uint64(band+1) * goldenRatio64
+	for _, v := range signature[band*l.rows : (band+1)*l.rows] {
+		hash = murmur3Mix64(hash ^ v)
+	}
+	return hash
+}
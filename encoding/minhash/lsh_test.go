@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_LSH(t *testing.T) {
+	var (
+		m, _   = minhash.NewMinHasher(128)
+		lsh, _ = minhash.NewLSH(32, 4)
+		docs   = map[string]minhash.Signature{
+			"a":       m.Signature(tokenRange(0, 500)),
+			"a-near":  m.Signature(tokenRange(10, 510)),
+			"b":       m.Signature(tokenRange(5000, 5500)),
+			"b-near":  m.Signature(tokenRange(5020, 5520)),
+			"distant": m.Signature(tokenRange(9000, 9500)),
+		}
+	)
+	for key, signature := range docs {
+		if err := lsh.Add(key, signature); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lsh.Len() != 5 {
+		t.Fatalf("Len = %d", lsh.Len())
+	}
+	if keys, err := lsh.Query(docs["a"]); err != nil || !reflect.DeepEqual(keys, []string{"a", "a-near"}) {
+		t.Fatalf("Query(a) = %v, %v", keys, err)
+	}
+	if keys, _ := lsh.Query(docs["b"], 0.99); !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Fatalf("Query(b) with threshold = %v", keys)
+	}
+	lsh.Remove("a-near")
+	if keys, _ := lsh.Query(docs["a"]); !reflect.DeepEqual(keys, []string{"a"}) || lsh.Len() != 4 {
+		t.Fatalf("Query(a) after Remove = %v", keys)
+	}
+	// Replacing the signature of a key removes its old bands.
+	if err := lsh.Add("distant", docs["a"]); err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := lsh.Query(docs["distant"]); len(keys) != 0 {
+		t.Fatalf("Query of the replaced signature = %v", keys)
+	}
+	if _, err := lsh.Query(docs["a"][:100]); err == nil {
+		t.Fatal("Query of short signature succeeds")
+	}
+	if err := lsh.Add("short", docs["a"][:100]); err == nil {
+		t.Fatal("Add of short signature succeeds")
+	}
+	if _, err := minhash.NewLSH(0, 4); err == nil {
+		t.Fatal("NewLSH of no band succeeds")
+	}
+}
+
+func Test_LSH_SignatureCopy(t *testing.T) {
+	var (
+		m, _      = minhash.NewMinHasher(16)
+		lsh, _    = minhash.NewLSH(4, 4)
+		signature = m.Signature(tokenRange(0, 10))
+	)
+	_ = lsh.Add("a", signature)
+	signature[0] = 0
+	got, ok := lsh.Signature("a")
+	if !ok || got[0] == 0 {
+		t.Fatal("Add does not copy the signature")
+	}
+	got[0] = 0
+	if again, _ := lsh.Signature("a"); again[0] == 0 {
+		t.Fatal("Signature returns the indexed signature")
+	}
+	if keys, _ := lsh.Query(m.Signature(tokenRange(0, 10))); !reflect.DeepEqual(keys, []string{"a"}) {
+		t.Fatalf("Query = %v after modifying the returned signature", keys)
+	}
+	if _, ok = lsh.Signature("missing"); ok {
+		t.Fatal("Signature of missing key is found")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// marshalVersion is the version of the binary format of the data structures in this package.
+	marshalVersion = 1
+)
+
+// marshalReader reads the binary format of the data structures in this package.
+// It records the first error and returns zero values after that, so callers can check the error once.
+type marshalReader struct {
+	data []byte
+	err  error
+}
+
+// appendHeader appends the common header, which is the 4 bytes `magic`, the format version
+// and the name of `hasher`, to `buf`. The name is empty if `hasher` is nil.
+func appendHeader(buf []byte, magic string, hasher Hasher) []byte {
+	buf = append(buf, magic...)
+	buf = append(buf, marshalVersion)
+	if hasher == nil {
+		return appendString(buf, "")
+	}
+	return appendString(buf, hasher.Name())
+}
+
+// appendString appends `s` prefixed with its length to `buf`.
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// newMarshalReader checks the common header of `data` for `magic`,
+// and returns a reader for the remaining data with the registered hasher of the header.
+// The header must have a hasher name unless `optionalHasher` is given as true,
+// the returned hasher is nil if the header has no hasher name in that case.
+func newMarshalReader(data []byte, magic string, optionalHasher ...bool) (*marshalReader, Hasher, error) {
+	if len(data) < len(magic)+1 || string(data[:len(magic)]) != magic {
+		return nil, nil, minerror.NewCodef(mincode.CodeInvalidParameter, `invalid binary data for "%s"`, magic)
+	}
+	if version := data[len(magic)]; version != marshalVersion {
+		return nil, nil, minerror.NewCodef(
+			mincode.CodeNotSupported, `unsupported binary version %d for "%s"`, version, magic,
+		)
+	}
+	r := &marshalReader{data: data[len(magic)+1:]}
+	name := r.string()
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	if name == "" && len(optionalHasher) > 0 && optionalHasher[0] {
+		return r, nil, nil
+	}
+	hasher, err := Lookup(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, hasher, nil
+}
+
+// fail records `err` as the error of the reader if there is no error yet.
+func (r *marshalReader) fail() {
+	if r.err == nil {
+		r.err = minerror.NewCode(mincode.CodeInvalidParameter, "unexpected end of binary data")
+	}
+	r.data = nil
+}
+
+// uvarint reads an unsigned varint.
+func (r *marshalReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// varint reads a signed varint.
+func (r *marshalReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// uint64 reads a little-endian uint64.
+func (r *marshalReader) uint64() uint64 {
+	if len(r.data) < 8 {
+		r.fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(r.data)
+	r.data = r.data[8:]
+	return v
+}
+
+// bytes reads `n` raw bytes, the result shares memory with the source data.
+func (r *marshalReader) bytes(n uint64) []byte {
+	if uint64(len(r.data)) < n {
+		r.fail()
+		return nil
+	}
+	v := r.data[:n]
+	r.data = r.data[n:]
+	return v
+}
+
+// string reads a string prefixed with its length.
+func (r *marshalReader) string() string {
+	return string(r.bytes(r.uvarint()))
+}
+
+// done returns the error of the reader, or an error if there are unread data.
+func (r *marshalReader) done() error {
+	if r.err == nil && len(r.data) > 0 {
+		r.err = minerror.NewCode(mincode.CodeInvalidParameter, "unexpected trailing binary data")
+	}
+	return r.err
+}
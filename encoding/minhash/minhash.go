@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"math"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// MinHasher generates MinHash signatures of token sets, whose similarity estimates
+// the Jaccard similarity of the sets. It is immutable and safe for concurrent use.
+type MinHasher struct {
+	hasher Hasher
+	seeds  []uint64 // Seed of each hash function, which simulates a random permutation.
+}
+
+// Signature is the MinHash signature of a token set.
+type Signature []uint64
+
+// NewMinHasher creates and returns a MinHasher generating signatures of `size` values.
+// The estimation error of Jaccard similarity is about 1/sqrt(size).
+// The tokens are hashed by optional `hasher`, which is xxh64 in default.
+// Signatures are only comparable if they are generated with the same size and hasher.
+func NewMinHasher(size int, hasher ...Hasher) (*MinHasher, error) {
+	if size <= 0 {
+		return nil, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid minhash signature size %d", size)
+	}
+	m := &MinHasher{
+		hasher: defaultHasher,
+		seeds:  make([]uint64, size),
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		m.hasher = hasher[0]
+	}
+	// The seeds are fixed, so that signatures are comparable across processes.
+	var seed uint64
+	for i := range m.seeds {
+		seed += goldenRatio64
+		m.seeds[i] = murmur3Mix64(seed)
+	}
+	return m, nil
+}
+
+// Signature returns the MinHash signature of the set of `tokens`.
+// Duplicated tokens do not affect the signature. All values of the signature of an empty set are MaxUint64.
+func (m *MinHasher) Signature(tokens [][]byte) Signature {
+	sig := make(Signature, len(m.seeds))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for _, token := range tokens {
+		hash := m.hasher.Sum64(token)
+		for i, seed := range m.seeds {
+			sig[i] = min(sig[i], murmur3Mix64(hash^seed))
+		}
+	}
+	return sig
+}
+
+// Size returns the number of values of the generated signatures.
+func (m *MinHasher) Size() int {
+	return len(m.seeds)
+}
+
+// Jaccard returns the estimated Jaccard similarity between the sets of signature `s` and `other`,
+// which is the fraction of equal values. It returns 0 if the signatures have different sizes.
+func (s Signature) Jaccard(other Signature) float64 {
+	if len(s) != len(other) || len(s) == 0 {
+		return 0
+	}
+	var equal int
+	for i, v := range s {
+		if v == other[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(s))
+}
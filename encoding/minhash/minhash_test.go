@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// tokenRange returns the tokens numbered from `from` to `to`, exclusive.
+func tokenRange(from, to int) [][]byte {
+	tokens := make([][]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		tokens = append(tokens, []byte("token:"+strconv.Itoa(i)))
+	}
+	return tokens
+}
+
+func Test_MinHasher_Jaccard(t *testing.T) {
+	m, err := minhash.NewMinHasher(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := m.Signature(tokenRange(0, 1000))
+	for _, c := range []struct {
+		from, to int
+		jaccard  float64
+	}{
+		{0, 1000, 1},
+		{100, 1100, 900.0 / 1100},
+		{500, 1500, 500.0 / 1500},
+		{900, 1900, 100.0 / 1900},
+		{1000, 2000, 0},
+	} {
+		// The estimation error is about 1/sqrt(256), 3 times of which is allowed.
+		if got := base.Jaccard(m.Signature(tokenRange(c.from, c.to))); math.Abs(got-c.jaccard) > 3.0/16 {
+			t.Errorf("Jaccard of [%d, %d) = %.3f, want %.3f", c.from, c.to, got, c.jaccard)
+		}
+	}
+	// Duplicated tokens and the order of tokens do not affect the signature.
+	tokens := tokenRange(0, 10)
+	reversed := append(tokenRange(0, 10), tokens...)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	if m.Signature(tokens).Jaccard(m.Signature(reversed)) != 1 {
+		t.Fatal("signature depends on duplicated tokens or order")
+	}
+	for _, v := range m.Signature(nil) {
+		if v != math.MaxUint64 {
+			t.Fatalf("signature of empty set has value %#x", v)
+		}
+	}
+	other, _ := minhash.NewMinHasher(128)
+	if base.Jaccard(other.Signature(tokenRange(0, 1000))) != 0 || m.Size() != 256 {
+		t.Fatal("Jaccard of signatures of different sizes is not 0")
+	}
+	if _, err = minhash.NewMinHasher(0); err == nil {
+		t.Fatal("NewMinHasher of size 0 succeeds")
+	}
+}
+
+func Test_MinHasher_Stable(t *testing.T) {
+	var (
+		m1, _ = minhash.NewMinHasher(64)
+		m2, _ = minhash.NewMinHasher(64)
+		s1    = m1.Signature(tokenRange(0, 100))
+		s2    = m2.Signature(tokenRange(0, 100))
+	)
+	if s1.Jaccard(s2) != 1 {
+		t.Fatal("signatures of different MinHashers differ")
+	}
+}
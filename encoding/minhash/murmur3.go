@@ -0,0 +1,214 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	murmur3C1x32  uint32 = 0xcc9e2d51
+	murmur3C2x32  uint32 = 0x1b873593
+	murmur3C1x128 uint64 = 0x87c37b91114253d5
+	murmur3C2x128 uint64 = 0x4cf5ad432745937f
+)
+
+// Murmur3 implements the MurmurHash3 x86_32 hash algorithm for 32 bits with seed 0.
+func Murmur3(str []byte) uint32 {
+	return Murmur3Seed(0, str)
+}
+
+// Murmur3String is the same as Murmur3 for string `str`, which hashes `str` without copying it.
+func Murmur3String(str string) uint32 {
+	return Murmur3(stringBytes(str))
+}
+
+// Murmur3Seed implements the MurmurHash3 x86_32 hash algorithm for 32 bits with given `seed`.
+func Murmur3Seed(seed uint32, str []byte) uint32 {
+	var (
+		hash   = seed
+		length = len(str)
+	)
+	for ; len(str) >= 4; str = str[4:] {
+		hash = murmur3Block32(hash, binary.LittleEndian.Uint32(str))
+	}
+	return murmur3Finalize32(hash, str, uint32(length))
+}
+
+// Murmur3SeedString is the same as Murmur3Seed for string `str`, which hashes `str` without copying it.
+func Murmur3SeedString(seed uint32, str string) uint32 {
+	return Murmur3Seed(seed, stringBytes(str))
+}
+
+// Murmur128 implements the MurmurHash3 x64_128 hash algorithm for 128 bits with seed 0.
+// The h1 and h2 of the reference implementation are returned as Hi and Lo respectively.
+func Murmur128(str []byte) Uint128 {
+	return Murmur128Seed(0, str)
+}
+
+// Murmur128String is the same as Murmur128 for string `str`, which hashes `str` without copying it.
+func Murmur128String(str string) Uint128 {
+	return Murmur128(stringBytes(str))
+}
+
+// Murmur128Seed implements the MurmurHash3 x64_128 hash algorithm for 128 bits with given `seed`.
+// The h1 and h2 of the reference implementation are returned as Hi and Lo respectively.
+func Murmur128Seed(seed uint32, str []byte) Uint128 {
+	var (
+		h1     = uint64(seed)
+		h2     = uint64(seed)
+		length = uint64(len(str))
+	)
+	for ; len(str) >= 16; str = str[16:] {
+		k1 := binary.LittleEndian.Uint64(str)
+		k2 := binary.LittleEndian.Uint64(str[8:])
+
+		k1 *= murmur3C1x128
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur3C2x128
+		h1 ^= k1
+		h1 = bits.RotateLeft64(h1, 27)
+		h1 += h2
+		h1 = h1*5 + 0x52dce729
+
+		k2 *= murmur3C2x128
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur3C1x128
+		h2 ^= k2
+		h2 = bits.RotateLeft64(h2, 31)
+		h2 += h1
+		h2 = h2*5 + 0x38495ab5
+	}
+
+	// Tail bytes, fewer than 16.
+	var k1, k2 uint64
+	for i := len(str) - 1; i >= 8; i-- {
+		k2 = k2<<8 | uint64(str[i])
+	}
+	if len(str) > 8 {
+		k2 *= murmur3C2x128
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur3C1x128
+		h2 ^= k2
+	}
+	for i := min(len(str), 8) - 1; i >= 0; i-- {
+		k1 = k1<<8 | uint64(str[i])
+	}
+	if len(str) > 0 {
+		k1 *= murmur3C1x128
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur3C2x128
+		h1 ^= k1
+	}
+
+	h1 ^= length
+	h2 ^= length
+	h1 += h2
+	h2 += h1
+	h1 = murmur3Mix64(h1)
+	h2 = murmur3Mix64(h2)
+	h1 += h2
+	h2 += h1
+	return Uint128{Hi: h1, Lo: h2}
+}
+
+// Murmur128SeedString is the same as Murmur128Seed for string `str`, which hashes `str` without copying it.
+func Murmur128SeedString(seed uint32, str string) Uint128 {
+	return Murmur128Seed(seed, stringBytes(str))
+}
+
+// murmur3Block32 mixes one 4 bytes block `k` into `hash`.
+func murmur3Block32(hash, k uint32) uint32 {
+	k *= murmur3C1x32
+	k = bits.RotateLeft32(k, 15)
+	k *= murmur3C2x32
+	hash ^= k
+	hash = bits.RotateLeft32(hash, 13)
+	return hash*5 + 0xe6546b64
+}
+
+// murmur3Finalize32 mixes the tail bytes, which are fewer than 4, and the total `length` into `hash`.
+func murmur3Finalize32(hash uint32, tail []byte, length uint32) uint32 {
+	var k uint32
+	for i := len(tail) - 1; i >= 0; i-- {
+		k = k<<8 | uint32(tail[i])
+	}
+	if len(tail) > 0 {
+		k *= murmur3C1x32
+		k = bits.RotateLeft32(k, 15)
+		k *= murmur3C2x32
+		hash ^= k
+	}
+	hash ^= length
+	hash ^= hash >> 16
+	hash *= 0x85ebca6b
+	hash ^= hash >> 13
+	hash *= 0xc2b2ae35
+	hash ^= hash >> 16
+	return hash
+}
+
+// murmur3Mix64 is the 64 bits finalization mix of MurmurHash3.
+func murmur3Mix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+type murmur3x32 struct {
+	seed   uint32
+	hash   uint32
+	tail   [4]byte // Pending bytes that do not fill a whole block yet.
+	n      int     // Number of pending bytes in tail.
+	length uint32  // Total number of written bytes.
+}
+
+// NewMurmur3 returns a new hash.Hash32 computing the MurmurHash3 x86_32 hash algorithm with seed 0.
+// Its Sum32 result is identical to Murmur3 for the same written data.
+func NewMurmur3() hash.Hash32 {
+	return NewMurmur3Seed(0)
+}
+
+// NewMurmur3Seed returns a new hash.Hash32 computing the MurmurHash3 x86_32 hash algorithm with given `seed`.
+// Its Sum32 result is identical to Murmur3Seed for the same written data.
+func NewMurmur3Seed(seed uint32) hash.Hash32 {
+	return &murmur3x32{seed: seed, hash: seed}
+}
+
+func (s *murmur3x32) Reset() { *s = murmur3x32{seed: s.seed, hash: s.seed} }
+
+func (s *murmur3x32) Sum32() uint32 { return murmur3Finalize32(s.hash, s.tail[:s.n], s.length) }
+
+func (s *murmur3x32) Write(data []byte) (int, error) {
+	length := len(data)
+	s.length += uint32(length)
+	if s.n > 0 {
+		c := copy(s.tail[s.n:], data)
+		s.n += c
+		data = data[c:]
+		if s.n < 4 {
+			return length, nil
+		}
+		s.hash = murmur3Block32(s.hash, binary.LittleEndian.Uint32(s.tail[:]))
+		s.n = 0
+	}
+	for ; len(data) >= 4; data = data[4:] {
+		s.hash = murmur3Block32(s.hash, binary.LittleEndian.Uint32(data))
+	}
+	s.n = copy(s.tail[:], data)
+	return length, nil
+}
+
+func (s *murmur3x32) Size() int { return 4 }
+
+func (s *murmur3x32) BlockSize() int { return 4 }
+
+func (s *murmur3x32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, s.Sum32()) }
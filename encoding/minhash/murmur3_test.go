@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Murmur3_Vectors(t *testing.T) {
+	// The vectors of the reference implementation of MurmurHash3 x86_32 and x64_128.
+	for _, v := range []struct {
+		seed uint32
+		str  string
+		h32  uint32
+		h128 minhash.Uint128
+	}{
+		{0x00, "", 0x00000000, minhash.Uint128{Hi: 0x0000000000000000, Lo: 0x0000000000000000}},
+		{0x00, "hello", 0x248bfa47, minhash.Uint128{Hi: 0xcbd8a7b341bd9b02, Lo: 0x5b1e906a48ae1d19}},
+		{0x00, "hello, world", 0x149bbb7f, minhash.Uint128{Hi: 0x342fac623a5ebc8e, Lo: 0x4cdcbc079642414d}},
+		{0x00, "19 Jan 2038 at 3:14:07 AM", 0xe31e8a70, minhash.Uint128{Hi: 0xb89e5988b737affc, Lo: 0x664fc2950231b2cb}},
+		{0x00, "The quick brown fox jumps over the lazy dog.", 0xd5c48bfc, minhash.Uint128{Hi: 0xcd99481f9ee902c9, Lo: 0x695da1a38987b6e7}},
+		{0x01, "", 0x514e28b7, minhash.Uint128{Hi: 0x4610abe56eff5cb5, Lo: 0x51622daa78f83583}},
+		{0x01, "hello", 0xbb4abcad, minhash.Uint128{Hi: 0xa78ddff5adae8d10, Lo: 0x128900ef20900135}},
+		{0x01, "hello, world", 0x6f5cb2e9, minhash.Uint128{Hi: 0x8b95f808840725c6, Lo: 0x1597ed5422bd493b}},
+		{0x01, "19 Jan 2038 at 3:14:07 AM", 0xf50e1f30, minhash.Uint128{Hi: 0x2a929de9c8f97b2f, Lo: 0x56a41d99af43a2db}},
+		{0x01, "The quick brown fox jumps over the lazy dog.", 0x846f6a36, minhash.Uint128{Hi: 0xfb3325171f9744da, Lo: 0xaaf8b92a5f722952}},
+		{0x2a, "", 0x087fcd5c, minhash.Uint128{Hi: 0xf02aa77dfa1b8523, Lo: 0xd1016610da11cbb9}},
+		{0x2a, "hello", 0xe2dbd2e1, minhash.Uint128{Hi: 0xc4b8b3c960af6f08, Lo: 0x2334b875b0efbc7a}},
+		{0x2a, "hello, world", 0x7ec7c6c2, minhash.Uint128{Hi: 0xb91864d797caa956, Lo: 0xd5d139a55afe6150}},
+		{0x2a, "19 Jan 2038 at 3:14:07 AM", 0x58f745f6, minhash.Uint128{Hi: 0xfd8f19ebdc8c6b6a, Lo: 0xd30fdc310fa08ff9}},
+		{0x2a, "The quick brown fox jumps over the lazy dog.", 0xc02d1434, minhash.Uint128{Hi: 0x74f33c659cda5af7, Lo: 0x4ec7a891caf316f0}},
+	} {
+		if got := minhash.Murmur3Seed(v.seed, []byte(v.str)); got != v.h32 {
+			t.Errorf("Murmur3Seed(%d, %q) = %#x, want %#x", v.seed, v.str, got, v.h32)
+		}
+		if got := minhash.Murmur128Seed(v.seed, []byte(v.str)); got != v.h128 {
+			t.Errorf("Murmur128Seed(%d, %q) = %#x, want %#x", v.seed, v.str, got, v.h128)
+		}
+		h := minhash.NewMurmur3Seed(v.seed)
+		h.Write([]byte(v.str))
+		if got := h.Sum32(); got != v.h32 {
+			t.Errorf("NewMurmur3Seed(%d) of %q = %#x, want %#x", v.seed, v.str, got, v.h32)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// perfectMagic is the magic of the binary format of PerfectHash.
+	perfectMagic = "MMPH"
+	// perfectBucketSize is the average number of keys in each bucket.
+	perfectBucketSize = 4
+	// perfectMaxSeed is the maximum seed tried for a bucket before giving up.
+	perfectMaxSeed = 1 << 24
+)
+
+// PerfectHash is a minimal perfect hash function of a static key set built with the
+// hash-and-displace algorithm, which maps the n keys to distinct indexes in [0, n).
+// The keys are grouped into buckets, and each bucket stores either the seed placing
+// all its keys into free slots, or the slot of its single key.
+// It takes about one byte per key, and is safe for concurrent use after being built.
+type PerfectHash struct {
+	hasher Hasher
+	n      uint64  // Number of keys.
+	values []int32 // Seed of each bucket if positive, or the negative slot of its single key minus 1.
+}
+
+// NewPerfectHash builds and returns the minimal perfect hash function of `keys`.
+// The keys are hashed by optional `hasher`, which is xxh64 in default and should be a 64 bits algorithm
+// for large key sets. It returns an error if `keys` is empty or contains duplicated keys or digests.
+func NewPerfectHash(keys [][]byte, hasher ...Hasher) (*PerfectHash, error) {
+	p := &PerfectHash{
+		hasher: defaultHasher,
+		n:      uint64(len(keys)),
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		p.hasher = hasher[0]
+	}
+	if len(keys) == 0 || len(keys) > math.MaxInt32 {
+		return nil, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid number %d of perfect hash keys", len(keys))
+	}
+	var (
+		seen    = make(map[uint64]int, len(keys))
+		buckets = make([][]uint64, (len(keys)+perfectBucketSize-1)/perfectBucketSize)
+	)
+	for i, key := range keys {
+		hash := p.hasher.Sum64(key)
+		if j, ok := seen[hash]; ok {
+			if bytes.Equal(keys[j], key) {
+				return nil, minerror.NewCodef(mincode.CodeInvalidParameter, `duplicated perfect hash key "%s"`, key)
+			}
+			return nil, minerror.NewCodef(
+				mincode.CodeInvalidParameter, `digest collision of perfect hash keys "%s" and "%s" by "%s"`,
+				keys[j], key, p.hasher.Name(),
+			)
+		}
+		seen[hash] = i
+		b := hash % uint64(len(buckets))
+		buckets[b] = append(buckets[b], hash)
+	}
+	// Place the largest buckets first, while there are still many free slots.
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+	var (
+		values   = make([]int32, len(buckets))
+		occupied = make([]bool, p.n)
+		slots    = make([]uint64, 0, perfectBucketSize*4)
+	)
+	for _, b := range order {
+		if len(buckets[b]) <= 1 {
+			break
+		}
+		seed := int32(1)
+		for ; ; seed++ {
+			if seed > perfectMaxSeed {
+				return nil, minerror.NewCode(mincode.CodeInternalError, "failed to build perfect hash")
+			}
+			var ok bool
+			if slots, ok = p.place(buckets[b], seed, occupied, slots[:0]); ok {
+				break
+			}
+		}
+		for _, slot := range slots {
+			occupied[slot] = true
+		}
+		values[b] = seed
+	}
+	// Each bucket of a single key takes a free slot directly.
+	var free uint64
+	for _, b := range order {
+		if len(buckets[b]) != 1 {
+			continue
+		}
+		for occupied[free] {
+			free++
+		}
+		occupied[free] = true
+		values[b] = -int32(free) - 1
+	}
+	p.values = values
+	return p, nil
+}
+
+// Index returns the index of `key` in [0, Len()), which is distinct for each key of the set.
+// The index of a key not in the set is arbitrary, so the caller should compare the key
+// with the key stored at the index if it may not be in the set.
+func (p *PerfectHash) Index(key []byte) uint64 {
+	hash := p.hasher.Sum64(key)
+	value := p.values[hash%uint64(len(p.values))]
+	if value < 0 {
+		return uint64(-value - 1)
+	}
+	return p.slot(hash, value)
+}
+
+// IndexString is the same as Index for string `key`, which hashes `key` without copying it.
+func (p *PerfectHash) IndexString(key string) uint64 {
+	return p.Index(stringBytes(key))
+}
+
+// Len returns the number of keys of the set.
+func (p *PerfectHash) Len() int {
+	return int(p.n)
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+// The hasher is recorded by name, so it must be registered for UnmarshalBinary.
+func (p *PerfectHash) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 16+2*len(p.values)), perfectMagic, p.hasher)
+	buf = binary.AppendUvarint(buf, p.n)
+	buf = binary.AppendUvarint(buf, uint64(len(p.values)))
+	for _, v := range p.values {
+		buf = binary.AppendVarint(buf, int64(v))
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (p *PerfectHash) UnmarshalBinary(data []byte) error {
+	r, hasher, err := newMarshalReader(data, perfectMagic)
+	if err != nil {
+		return err
+	}
+	n, buckets := r.uvarint(), r.uvarint()
+	if r.err != nil {
+		return r.err
+	}
+	// Each value takes at least one byte.
+	if n == 0 || n > math.MaxInt32 || buckets == 0 || buckets > uint64(len(r.data)) {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for perfect hash")
+	}
+	values := make([]int32, buckets)
+	for i := range values {
+		v := r.varint()
+		if v > perfectMaxSeed || v < -int64(n) {
+			return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for perfect hash")
+		}
+		values[i] = int32(v)
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	*p = PerfectHash{hasher: hasher, n: n, values: values}
+	return nil
+}
+
+// place appends the slots of `hashes` with `seed` to `slots`, and returns false
+// if any slot is occupied or taken by another hash of the bucket.
+func (p *PerfectHash) place(hashes []uint64, seed int32, occupied []bool, slots []uint64) ([]uint64, bool) {
+	for _, hash := range hashes {
+		slot := p.slot(hash, seed)
+		if occupied[slot] {
+			return slots, false
+		}
+		for _, s := range slots {
+			if s == slot {
+				return slots, false
+			}
+		}
+		slots = append(slots, slot)
+	}
+	return slots, true
+}
+
+// slot returns the slot of `hash` with `seed`.
+func (p *PerfectHash) slot(hash uint64, seed int32) uint64 {
+	return murmur3Mix64(hash+uint64(seed)*goldenRatio64) % p.n
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// checkPerfect checks whether `p` maps `keys` to distinct indexes in [0, len(keys)).
+func checkPerfect(t *testing.T, p *minhash.PerfectHash, keys []string) {
+	t.Helper()
+	if p.Len() != len(keys) {
+		t.Fatalf("Len = %d, want %d", p.Len(), len(keys))
+	}
+	seen := make([]bool, len(keys))
+	for _, key := range keys {
+		index := p.IndexString(key)
+		if index >= uint64(len(keys)) || seen[index] {
+			t.Fatalf("index %d of key %s is out of range or duplicated", index, key)
+		}
+		if p.Index([]byte(key)) != index {
+			t.Fatalf("Index and IndexString of key %s differ", key)
+		}
+		seen[index] = true
+	}
+}
+
+func Test_PerfectHash(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 100, 10000, 100000} {
+		var (
+			keys  = benchmarkKeys(n)
+			input = make([][]byte, n)
+		)
+		for i, key := range keys {
+			input[i] = []byte(key)
+		}
+		p, err := minhash.NewPerfectHash(input)
+		if err != nil {
+			t.Fatalf("%d keys: %v", n, err)
+		}
+		checkPerfect(t, p, keys)
+		data, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// It takes about one byte per key, with the header and varint overhead.
+		if n >= 10000 && len(data) > 3*n/2 {
+			t.Errorf("%d keys take %d bytes", n, len(data))
+		}
+		var result minhash.PerfectHash
+		if err = result.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%d keys: UnmarshalBinary failed: %v", n, err)
+		}
+		checkPerfect(t, &result, keys)
+		for _, key := range keys {
+			if result.IndexString(key) != p.IndexString(key) {
+				t.Fatalf("%d keys: unmarshaled index of %s differs", n, key)
+			}
+		}
+		if result.UnmarshalBinary(data[:len(data)-1]) == nil {
+			t.Fatalf("%d keys: UnmarshalBinary of truncated data succeeds", n)
+		}
+	}
+}
+
+func Test_PerfectHash_Invalid(t *testing.T) {
+	if _, err := minhash.NewPerfectHash(nil); err == nil {
+		t.Error("NewPerfectHash of no key succeeds")
+	}
+	if _, err := minhash.NewPerfectHash([][]byte{[]byte("a"), []byte("b"), []byte("a")}); err == nil {
+		t.Error("NewPerfectHash of duplicated keys succeeds")
+	}
+	// The keys "B\x00" and "A\x83" collide in BKDR.
+	bkdr, _ := minhash.Lookup("bkdr32")
+	if _, err := minhash.NewPerfectHash([][]byte{[]byte("B\x00"), []byte("A\x83")}, bkdr); err == nil {
+		t.Error("NewPerfectHash of colliding keys succeeds")
+	}
+}
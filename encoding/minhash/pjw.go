@@ -5,14 +5,31 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // PJW implements the classic PJW hash algorithm for 32 bits.
 func PJW(str []byte) uint32 {
+	return PJWSeed(0, str)
+}
+
+// PJWString is the same as PJW for string `str`, which hashes `str` without copying it.
+func PJWString(str string) uint32 {
+	return PJW(stringBytes(str))
+}
+
+// PJWSeed implements the classic PJW hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as PJW.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func PJWSeed(seed uint32, str []byte) uint32 {
 	var (
 		BitsInUnsignedInt uint32 = 32 // 4 * 8
 		ThreeQuarters            = (BitsInUnsignedInt * 3) / 4
 		OneEighth                = BitsInUnsignedInt / 8
 		HighBits          uint32 = (0xFFFFFFFF) << (BitsInUnsignedInt - OneEighth)
-		hash              uint32
+		hash                     = seed
 		test              uint32
 	)
 	for _, b := range str {
@@ -24,14 +41,31 @@ func PJW(str []byte) uint32 {
 	return hash
 }
 
+// PJWSeedString is the same as PJWSeed for string `str`, which hashes `str` without copying it.
+func PJWSeedString(seed uint32, str string) uint32 {
+	return PJWSeed(seed, stringBytes(str))
+}
+
 // PJW64 implements the classic PJW hash algorithm for 64 bits.
 func PJW64(str []byte) uint64 {
+	return PJW64Seed(0, str)
+}
+
+// PJW64String is the same as PJW64 for string `str`, which hashes `str` without copying it.
+func PJW64String(str string) uint64 {
+	return PJW64(stringBytes(str))
+}
+
+// PJW64Seed implements the classic PJW hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as PJW64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func PJW64Seed(seed uint64, str []byte) uint64 {
 	var (
 		BitsInUnsignedInt uint64 = 64 // 8 * 8
 		ThreeQuarters            = (BitsInUnsignedInt * 3) / 4
 		OneEighth                = BitsInUnsignedInt / 8
 		HighBits          uint64 = (0xFFFFFFFFFFFFFFFF) << (BitsInUnsignedInt - OneEighth)
-		hash              uint64
+		hash                     = seed
 		test              uint64
 	)
 	for _, b := range str {
@@ -42,3 +76,71 @@ func PJW64(str []byte) uint64 {
 	}
 	return hash
 }
+
+// PJW64SeedString is the same as PJW64Seed for string `str`, which hashes `str` without copying it.
+func PJW64SeedString(seed uint64, str string) uint64 {
+	return PJW64Seed(seed, stringBytes(str))
+}
+
+const (
+	pjwHighBits32 uint32 = 0xF0000000         // (0xFFFFFFFF) << (32 - 32/8)
+	pjwHighBits64 uint64 = 0xFF00000000000000 // (0xFFFFFFFFFFFFFFFF) << (64 - 64/8)
+)
+
+type (
+	pjw32 uint32
+	pjw64 uint64
+)
+
+// NewPJW32 returns a new hash.Hash32 computing the PJW hash algorithm for 32 bits.
+// Its Sum32 result is identical to PJW for the same written data.
+func NewPJW32() hash.Hash32 {
+	var s pjw32
+	return &s
+}
+
+// NewPJW64 returns a new hash.Hash64 computing the PJW hash algorithm for 64 bits.
+// Its Sum64 result is identical to PJW64 for the same written data.
+func NewPJW64() hash.Hash64 {
+	var s pjw64
+	return &s
+}
+
+func (s *pjw32) Reset() { *s = 0 }
+func (s *pjw64) Reset() { *s = 0 }
+
+func (s *pjw32) Sum32() uint32 { return uint32(*s) }
+func (s *pjw64) Sum64() uint64 { return uint64(*s) }
+
+func (s *pjw32) Write(data []byte) (int, error) {
+	var hash, test = uint32(*s), uint32(0)
+	for _, b := range data {
+		hash = (hash << (32 / 8)) + uint32(b)
+		if test = hash & pjwHighBits32; test != 0 {
+			hash = (hash ^ (test >> (32 * 3 / 4))) & (^pjwHighBits32 + 1)
+		}
+	}
+	*s = pjw32(hash)
+	return len(data), nil
+}
+
+func (s *pjw64) Write(data []byte) (int, error) {
+	var hash, test = uint64(*s), uint64(0)
+	for _, b := range data {
+		hash = (hash << (64 / 8)) + uint64(b)
+		if test = hash & pjwHighBits64; test != 0 {
+			hash = (hash ^ (test >> (64 * 3 / 4))) & (^pjwHighBits64 + 1)
+		}
+	}
+	*s = pjw64(hash)
+	return len(data), nil
+}
+
+func (s *pjw32) Size() int { return 4 }
+func (s *pjw64) Size() int { return 8 }
+
+func (s *pjw32) BlockSize() int { return 1 }
+func (s *pjw64) BlockSize() int { return 1 }
+
+func (s *pjw32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *pjw64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
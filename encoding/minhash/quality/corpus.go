@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package quality
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// corpusSeed is the fixed seed of the random corpora, so that reports are reproducible.
+const corpusSeed = 0x6d696e68617368
+
+// Corpus is a named generator of test keys with a particular shape.
+type Corpus struct {
+	Name     string               // Name of the corpus, eg: "ints".
+	Generate func(n int) [][]byte // Generate returns `n` distinct keys.
+}
+
+// urlSegments are the path segments of the URL paths corpus.
+var urlSegments = []string{
+	"api", "v1", "v2", "users", "orders", "items", "products", "accounts",
+	"search", "static", "images", "assets", "admin", "settings", "profile", "reports",
+}
+
+// SequentialInts returns the corpus of sequential decimal integers, eg: "0", "1", "2".
+func SequentialInts() Corpus {
+	return Corpus{
+		Name: "ints",
+		Generate: func(n int) [][]byte {
+			keys := make([][]byte, n)
+			for i := range keys {
+				keys[i] = strconv.AppendInt(nil, int64(i), 10)
+			}
+			return keys
+		},
+	}
+}
+
+// UUIDs returns the corpus of random version 4 UUIDs in canonical text form.
+func UUIDs() Corpus {
+	return Corpus{
+		Name: "uuids",
+		Generate: func(n int) [][]byte {
+			var (
+				r    = rand.New(rand.NewPCG(corpusSeed, 1))
+				keys = make([][]byte, n)
+			)
+			for i := range keys {
+				hi, lo := r.Uint64(), r.Uint64()
+				hi = hi&^0xF000 | 0x4000
+				lo = lo&^(0xC<<60) | 0x8<<60
+				keys[i] = fmt.Appendf(
+					nil, "%08x-%04x-%04x-%04x-%012x",
+					hi>>32, hi>>16&0xFFFF, hi&0xFFFF, lo>>48, lo&0xFFFFFFFFFFFF,
+				)
+			}
+			return keys
+		},
+	}
+}
+
+// URLPaths returns the corpus of URL paths of common words and numeric identifiers,
+// eg: "/api/v1/users/1024/orders".
+func URLPaths() Corpus {
+	return Corpus{
+		Name: "paths",
+		Generate: func(n int) [][]byte {
+			var (
+				r    = rand.New(rand.NewPCG(corpusSeed, 2))
+				keys = make([][]byte, n)
+			)
+			for i := range keys {
+				var b strings.Builder
+				for depth := 1 + r.IntN(4); depth > 0; depth-- {
+					b.WriteByte('/')
+					b.WriteString(urlSegments[r.IntN(len(urlSegments))])
+				}
+				// The sequence number makes keys distinct while keeping the shape of real paths.
+				b.WriteByte('/')
+				b.WriteString(strconv.Itoa(i))
+				keys[i] = []byte(b.String())
+			}
+			return keys
+		},
+	}
+}
+
+// Corpora returns all built-in corpora.
+func Corpora() []Corpus {
+	return []Corpus{SequentialInts(), UUIDs(), URLPaths()}
+}
+
+// LookupCorpus returns the built-in corpus by `name`.
+func LookupCorpus(name string) (Corpus, error) {
+	for _, c := range Corpora() {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Corpus{}, minerror.NewCodef(mincode.CodeNotFound, `corpus "%s" is not found`, name)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+// Package quality provides statistical analysis of the hash algorithms of package minhash,
+// which measures avalanche, bit independence, bucket distribution and collisions over key corpora.
+package quality
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"text/tabwriter"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+const (
+	defaultKeys    = 100000
+	defaultBuckets = 1024
+	defaultSamples = 1000
+	// maxInputBits is the maximum number of leading input bits flipped for each sample key.
+	maxInputBits = 128
+)
+
+// Option is the option for the analysis.
+type Option struct {
+	Keys    int // Number of keys of each corpus for distribution and collision tests, it is 100000 in default.
+	Buckets int // Number of buckets for the chi-square test, it is 1024 in default.
+	Samples int // Number of keys of each corpus for avalanche tests, it is 1000 in default.
+}
+
+// Result is the analysis result of an algorithm over a corpus.
+type Result struct {
+	Algorithm          string  // Name of the algorithm.
+	Corpus             string  // Name of the corpus.
+	Bits               int     // Number of bits of the digest.
+	Avalanche          float64 // Mean bias of output bit flips for input bit flips, 0 is ideal and 1 is worst.
+	AvalancheWorst     float64 // Worst bias of output bit flips for any pair of input and output bit.
+	BitIndependence    float64 // Worst absolute correlation between the flips of two output bits, 0 is ideal.
+	ChiSquare          float64 // Chi-square of the buckets divided by its degrees of freedom, 1 is ideal.
+	Collisions         int     // Number of keys colliding with a previous key.
+	ExpectedCollisions float64 // Expected number of collisions of a random function.
+}
+
+// Run analyzes all `hashers` over all `corpora`, the results are ordered by corpus, then by hasher.
+func Run(hashers []minhash.Hasher, corpora []Corpus, option ...Option) []Result {
+	results := make([]Result, 0, len(hashers)*len(corpora))
+	for _, corpus := range corpora {
+		for _, hasher := range hashers {
+			results = append(results, Analyze(hasher, corpus, option...))
+		}
+	}
+	return results
+}
+
+// Analyze analyzes `hasher` over `corpus`.
+func Analyze(hasher minhash.Hasher, corpus Corpus, option ...Option) Result {
+	opt := Option{
+		Keys:    defaultKeys,
+		Buckets: defaultBuckets,
+		Samples: defaultSamples,
+	}
+	if len(option) > 0 {
+		if option[0].Keys > 0 {
+			opt.Keys = option[0].Keys
+		}
+		if option[0].Buckets > 0 {
+			opt.Buckets = option[0].Buckets
+		}
+		if option[0].Samples > 0 {
+			opt.Samples = option[0].Samples
+		}
+	}
+	var (
+		keys   = corpus.Generate(max(opt.Keys, opt.Samples))
+		result = Result{
+			Algorithm: hasher.Name(),
+			Corpus:    corpus.Name,
+			Bits:      hasher.Size() * 8,
+		}
+	)
+	result.Avalanche, result.AvalancheWorst, result.BitIndependence = avalanche(hasher, keys[:opt.Samples], result.Bits)
+	result.ChiSquare = chiSquare(hasher, keys[:opt.Keys], opt.Buckets)
+	result.Collisions = collisions(hasher, keys[:opt.Keys])
+	result.ExpectedCollisions = expectedCollisions(opt.Keys, result.Bits)
+	return result
+}
+
+// Report writes the comparative report of `results` as a table to `writer`.
+func Report(writer io.Writer, results []Result) error {
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "CORPUS\tALGORITHM\tBITS\tAVALANCHE\tWORST\tBIC\tCHI2\tCOLLISIONS\tEXPECTED\t")
+	for _, r := range results {
+		fmt.Fprintf(
+			w, "%s\t%s\t%d\t%.4f\t%.4f\t%.4f\t%.3f\t%d\t%.2f\t\n",
+			r.Corpus, r.Algorithm, r.Bits, r.Avalanche, r.AvalancheWorst,
+			r.BitIndependence, r.ChiSquare, r.Collisions, r.ExpectedCollisions,
+		)
+	}
+	return w.Flush()
+}
+
+// avalanche flips each of the leading input bits of `keys` and returns the mean and worst bias
+// of the output bit flips, and the worst correlation between the flips of two output bits.
+func avalanche(hasher minhash.Hasher, keys [][]byte, outputBits int) (mean, worst, independence float64) {
+	var (
+		flips  = make([][]int, maxInputBits)        // Flips of each output bit for each input bit.
+		trials = make([]int, maxInputBits)          // Trials of each input bit.
+		pairs  = make([]int, outputBits*outputBits) // Trials flipping both output bits.
+		total  int
+		buf    []byte
+	)
+	for i := range flips {
+		flips[i] = make([]int, outputBits)
+	}
+	for _, key := range keys {
+		buf = append(buf[:0], key...)
+		origin := hasher.Sum64(buf)
+		for i := 0; i < min(len(buf)*8, maxInputBits); i++ {
+			buf[i/8] ^= 1 << (i % 8)
+			diff := hasher.Sum64(buf) ^ origin
+			buf[i/8] ^= 1 << (i % 8)
+			trials[i]++
+			total++
+			for d := diff; d != 0; d &= d - 1 {
+				j := bits.TrailingZeros64(d)
+				flips[i][j]++
+				for e := d & (d - 1); e != 0; e &= e - 1 {
+					pairs[j*outputBits+bits.TrailingZeros64(e)]++
+				}
+			}
+		}
+	}
+	// Flips of each output bit over all input bits, for the bit independence.
+	var (
+		outputFlips = make([]int, outputBits)
+		count       int
+	)
+	for i, n := range trials {
+		if n == 0 {
+			continue
+		}
+		for j, f := range flips[i] {
+			bias := math.Abs(2*float64(f)/float64(n) - 1)
+			mean += bias
+			worst = max(worst, bias)
+			count++
+			outputFlips[j] += f
+		}
+	}
+	if count == 0 {
+		return 0, 0, 0
+	}
+	mean /= float64(count)
+	for j := 0; j < outputBits; j++ {
+		for k := j + 1; k < outputBits; k++ {
+			var (
+				pj  = float64(outputFlips[j]) / float64(total)
+				pk  = float64(outputFlips[k]) / float64(total)
+				pjk = float64(pairs[j*outputBits+k]) / float64(total)
+				v   = pj * (1 - pj) * pk * (1 - pk)
+			)
+			if v == 0 {
+				// An output bit which always or never flips is fully dependent.
+				independence = 1
+				continue
+			}
+			independence = max(independence, math.Abs(pjk-pj*pk)/math.Sqrt(v))
+		}
+	}
+	return mean, worst, independence
+}
+
+// chiSquare distributes `keys` into `buckets` by the digest modulo the number of buckets,
+// and returns the chi-square statistic divided by its degrees of freedom.
+func chiSquare(hasher minhash.Hasher, keys [][]byte, buckets int) float64 {
+	if buckets < 2 || len(keys) == 0 {
+		return 0
+	}
+	counts := make([]int, buckets)
+	for _, key := range keys {
+		counts[hasher.Sum64(key)%uint64(buckets)]++
+	}
+	var (
+		expected = float64(len(keys)) / float64(buckets)
+		chi2     float64
+	)
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi2 += d * d / expected
+	}
+	return chi2 / float64(buckets-1)
+}
+
+// collisions returns the number of `keys` whose digest equals the digest of a previous key.
+func collisions(hasher minhash.Hasher, keys [][]byte) int {
+	seen := make(map[uint64]struct{}, len(keys))
+	for _, key := range keys {
+		seen[hasher.Sum64(key)] = struct{}{}
+	}
+	return len(keys) - len(seen)
+}
+
+// expectedCollisions returns the expected number of collisions of `n` keys for a random function of `outputBits`.
+func expectedCollisions(n, outputBits int) float64 {
+	m := math.Ldexp(1, outputBits)
+	if float64(n)/m < 1e-6 {
+		// The approximation avoids the precision loss of the exact formula for large digests.
+		return float64(n) * float64(n-1) / (2 * m)
+	}
+	return float64(n) - m*-math.Expm1(float64(n)*math.Log1p(-1/m))
+}
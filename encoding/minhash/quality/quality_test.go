@@ -0,0 +1,84 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package quality_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+	"github.com/focela/min/encoding/minhash/quality"
+)
+
+func Test_Corpora(t *testing.T) {
+	for _, corpus := range quality.Corpora() {
+		keys := corpus.Generate(10000)
+		seen := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			if len(key) == 0 || seen[string(key)] {
+				t.Fatalf("%s: key %q is empty or duplicated", corpus.Name, key)
+			}
+			seen[string(key)] = true
+		}
+		// The corpora are reproducible.
+		if !reflect.DeepEqual(keys, corpus.Generate(10000)) {
+			t.Fatalf("%s: keys differ between generations", corpus.Name)
+		}
+		if c, err := quality.LookupCorpus(corpus.Name); err != nil || c.Name != corpus.Name {
+			t.Fatalf("LookupCorpus(%s) = %v, %v", corpus.Name, c.Name, err)
+		}
+	}
+	if _, err := quality.LookupCorpus("missing"); err == nil {
+		t.Fatal("LookupCorpus of missing corpus succeeds")
+	}
+}
+
+func Test_Analyze(t *testing.T) {
+	var (
+		xxh64, _   = minhash.Lookup("xxh64")
+		murmur3, _ = minhash.Lookup("murmur3")
+		// The prefix hasher takes the first 8 bytes of keys, which has no avalanche at all.
+		prefix = minhash.NewHasher64("prefix", func(data []byte) uint64 {
+			var u uint64
+			for i := 0; i < min(len(data), 8); i++ {
+				u |= uint64(data[i]) << (8 * i)
+			}
+			return u
+		}, nil)
+		option  = quality.Option{Keys: 20000, Buckets: 256, Samples: 200}
+		results = quality.Run([]minhash.Hasher{xxh64, murmur3, prefix}, []quality.Corpus{quality.UUIDs()}, option)
+	)
+	if len(results) != 3 {
+		t.Fatalf("Run returns %d results", len(results))
+	}
+	// The bias of an ideal hash over 200 samples is about 0.056 by the sampling noise.
+	for _, r := range results[:2] {
+		if r.Corpus != "uuids" || r.Avalanche > 0.08 || r.BitIndependence > 0.2 || r.ChiSquare < 0.7 || r.ChiSquare > 1.3 {
+			t.Errorf("good hash is reported as %+v", r)
+		}
+	}
+	if r := results[0]; r.Bits != 64 || r.Collisions != 0 || r.ExpectedCollisions > 1e-9 {
+		t.Errorf("64 bits hash is reported as %+v", r)
+	}
+	// About n^2/2^33 collisions are expected for 32 bits.
+	if r := results[1]; r.Bits != 32 || math.Abs(r.ExpectedCollisions-20000.0*19999/(1<<33)) > 1e-3 {
+		t.Errorf("32 bits hash is reported as %+v", r)
+	}
+	if r := results[2]; r.Avalanche < 0.9 || r.AvalancheWorst != 1 || r.ChiSquare < 10 {
+		t.Errorf("prefix hash is reported as %+v", r)
+	}
+	var buf bytes.Buffer
+	if err := quality.Report(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[0], "AVALANCHE") || !strings.Contains(lines[3], "prefix") {
+		t.Fatalf("Report = %s", buf.String())
+	}
+}
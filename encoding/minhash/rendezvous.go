@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"math"
+	"sort"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// Rendezvous implements the weighted Rendezvous hashing, also known as Highest Random Weight hashing.
+// Each key is placed on the node with the highest score computed from the key and the node,
+// so that removing a node only moves the keys it owned.
+// It is immutable after creation and safe for concurrent use.
+type Rendezvous struct {
+	nodes []rendezvousNode
+}
+
+// RendezvousNode is a node for Rendezvous hashing.
+type RendezvousNode struct {
+	Name   string  // Unique name of the node.
+	Weight float64 // Weight of the node, it is 1 if not specified.
+}
+
+// rendezvousNode is a node with its precomputed name hash.
+type rendezvousNode struct {
+	name   string
+	hash   uint64
+	weight float64
+}
+
+// NewRendezvous creates and returns a Rendezvous over `nodes`.
+// The node names are hashed by optional `hasher`, which is xxh64 in default.
+func NewRendezvous(nodes []RendezvousNode, hasher ...Hasher) (*Rendezvous, error) {
+	h := defaultHasher
+	if len(hasher) > 0 && hasher[0] != nil {
+		h = hasher[0]
+	}
+	var (
+		r    = &Rendezvous{nodes: make([]rendezvousNode, 0, len(nodes))}
+		seen = make(map[string]struct{}, len(nodes))
+	)
+	for _, node := range nodes {
+		if node.Name == "" {
+			return nil, minerror.NewCode(mincode.CodeInvalidParameter, "rendezvous node name should not be empty")
+		}
+		if _, ok := seen[node.Name]; ok {
+			return nil, minerror.NewCodef(mincode.CodeInvalidParameter, `duplicated rendezvous node "%s"`, node.Name)
+		}
+		if node.Weight < 0 || math.IsNaN(node.Weight) || math.IsInf(node.Weight, 0) {
+			return nil, minerror.NewCodef(
+				mincode.CodeInvalidParameter, `invalid weight %v for rendezvous node "%s"`, node.Weight, node.Name,
+			)
+		}
+		seen[node.Name] = struct{}{}
+		weight := node.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		r.nodes = append(r.nodes, rendezvousNode{
+			name:   node.Name,
+			hash:   SumString(h, node.Name),
+			weight: weight,
+		})
+	}
+	return r, nil
+}
+
+// Get returns the name of the node owning `key`, which is usually produced by a 64 bits
+// hash function of this package. It does not allocate memory.
+// It returns an empty string if there is no node.
+func (r *Rendezvous) Get(key uint64) string {
+	var (
+		best      = -1
+		bestScore float64
+	)
+	for i := range r.nodes {
+		if score := r.nodes[i].score(key); best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return r.nodes[best].name
+}
+
+// GetN returns the names of at most `n` nodes for `key` in descending order of their scores.
+// It is usually used for choosing replicas.
+func (r *Rendezvous) GetN(key uint64, n int) []string {
+	if n > len(r.nodes) {
+		n = len(r.nodes)
+	}
+	if n <= 0 {
+		return nil
+	}
+	type scored struct {
+		index int
+		score float64
+	}
+	scores := make([]scored, len(r.nodes))
+	for i := range r.nodes {
+		scores[i] = scored{index: i, score: r.nodes[i].score(key)}
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].score > scores[j].score
+	})
+	names := make([]string, n)
+	for i := range names {
+		names[i] = r.nodes[scores[i].index].name
+	}
+	return names
+}
+
+// Len returns the number of nodes.
+func (r *Rendezvous) Len() int {
+	return len(r.nodes)
+}
+
+// score returns the weighted score of the node for `key`, which is -weight/ln(u),
+// where u is a uniform number in (0, 1) derived from the key and the node.
+func (n *rendezvousNode) score(key uint64) float64 {
+	u := (float64(murmur3Mix64(key^n.hash)>>11) + 0.5) / (1 << 53)
+	return -n.weight / math.Log(u)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Rendezvous(t *testing.T) {
+	nodes := []minhash.RendezvousNode{{Name: "a"}, {Name: "b"}, {Name: "c", Weight: 2}}
+	r, err := minhash.NewRendezvous(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	less, _ := minhash.NewRendezvous(nodes[:2])
+	var (
+		keys   = benchmarkKeys(40000)
+		counts = make(map[string]int)
+	)
+	for _, key := range keys {
+		var (
+			hash  = minhash.XXH64String(key)
+			owner = r.Get(hash)
+		)
+		counts[owner]++
+		// Removing a node only moves the keys it owned.
+		if owner != "c" && less.Get(hash) != owner {
+			t.Fatalf("key %s moves from %s to %s", key, owner, less.Get(hash))
+		}
+		if top := r.GetN(hash, 5); len(top) != 3 || top[0] != owner {
+			t.Fatalf("GetN = %v, owner %s", top, owner)
+		}
+	}
+	// The weight units are 4, and each node is allowed 10% away from its share.
+	for name, want := range map[string]int{"a": 10000, "b": 10000, "c": 20000} {
+		if counts[name] < want*9/10 || counts[name] > want*11/10 {
+			t.Errorf("%s owns %d keys, want about %d", name, counts[name], want)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { r.Get(42) }); n != 0 {
+		t.Errorf("Get allocates %v times", n)
+	}
+}
+
+func Test_Rendezvous_Invalid(t *testing.T) {
+	empty, err := minhash.NewRendezvous(nil)
+	if err != nil || empty.Get(1) != "" || empty.GetN(1, 2) != nil || empty.Len() != 0 {
+		t.Fatal("empty rendezvous returns nodes")
+	}
+	for _, nodes := range [][]minhash.RendezvousNode{
+		{{Name: ""}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Weight: -1}},
+		{{Name: "a", Weight: math.NaN()}},
+		{{Name: "a", Weight: math.Inf(1)}},
+	} {
+		if _, err = minhash.NewRendezvous(nodes); err == nil {
+			t.Errorf("NewRendezvous(%v) succeeds", nodes)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// defaultRingReplicas is the default number of virtual nodes for each unit of member weight.
+	defaultRingReplicas = 160
+)
+
+// Ring is a consistent hashing ring with virtual nodes and weighted members.
+// The placement of keys only depends on the members, their weights and the hash algorithm,
+// so that the same key maps to the same member across processes.
+// It is safe for concurrent use.
+type Ring struct {
+	mu       sync.RWMutex
+	hasher   Hasher         // Hash algorithm for both keys and virtual nodes.
+	replicas int            // Number of virtual nodes for each unit of member weight.
+	weights  map[string]int // Members and their weights.
+	points   []ringPoint    // Virtual nodes in ascending order of their hash.
+}
+
+// RingOption is the option for creating a Ring.
+type RingOption struct {
+	Hasher   Hasher // Hash algorithm, it is xxh64 in default.
+	Replicas int    // Number of virtual nodes for each unit of member weight, it is 160 in default.
+}
+
+// ringPoint is a virtual node on the ring.
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// NewRing creates and returns an empty consistent hashing ring.
+func NewRing(option ...RingOption) *Ring {
+	r := &Ring{
+		hasher:   defaultHasher,
+		replicas: defaultRingReplicas,
+		weights:  make(map[string]int),
+	}
+	if len(option) > 0 {
+		if option[0].Hasher != nil {
+			r.hasher = option[0].Hasher
+		}
+		if option[0].Replicas > 0 {
+			r.replicas = option[0].Replicas
+		}
+	}
+	return r
+}
+
+// Add adds member `node` to the ring with optional `weight`, which is 1 in default.
+// A member with weight N owns N times more virtual nodes than a member with weight 1.
+// Adding an existing member updates its weight.
+func (r *Ring) Add(node string, weight ...int) error {
+	w := 1
+	if len(weight) > 0 {
+		w = weight[0]
+	}
+	if node == "" {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "ring node should not be empty")
+	}
+	if w <= 0 {
+		return minerror.NewCodef(mincode.CodeInvalidParameter, `invalid weight %d for ring node "%s"`, w, node)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.weights[node]; ok {
+		if old == w {
+			return nil
+		}
+		r.removePoints(node)
+	}
+	r.weights[node] = w
+	for i := 0; i < w*r.replicas; i++ {
+		r.points = append(r.points, ringPoint{
+			hash: r.hasher.Sum64([]byte(node + "#" + strconv.Itoa(i))),
+			node: node,
+		})
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+	return nil
+}
+
+// Remove removes member `node` and all its virtual nodes from the ring.
+func (r *Ring) Remove(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.weights[node]; !ok {
+		return
+	}
+	delete(r.weights, node)
+	r.removePoints(node)
+}
+
+// Get returns the member owning `key`.
+// It returns an empty string if the ring has no member.
+func (r *Ring) Get(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return ""
+	}
+	return r.points[r.search(key)].node
+}
+
+// GetN returns at most `n` distinct members for `key`, walking the ring clockwise
+// from the owner of `key`. It is usually used for choosing replicas.
+func (r *Ring) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if n > len(r.weights) {
+		n = len(r.weights)
+	}
+	if n <= 0 {
+		return nil
+	}
+	var (
+		nodes = make([]string, 0, n)
+		seen  = make(map[string]struct{}, n)
+	)
+	for i, start := 0, r.search(key); len(nodes) < n; i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if _, ok := seen[node]; ok {
+			continue
+		}
+		seen[node] = struct{}{}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// Nodes returns all members of the ring in ascending order.
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	nodes := make([]string, 0, len(r.weights))
+	for node := range r.weights {
+		nodes = append(nodes, node)
+	}
+	r.mu.RUnlock()
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Weight returns the weight of member `node`, or 0 if it is not a member.
+func (r *Ring) Weight(node string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.weights[node]
+}
+
+// Len returns the number of members of the ring.
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.weights)
+}
+
+// search returns the index of the first virtual node at or after the hash of `key`.
+// The ring must not be empty.
+func (r *Ring) search(key string) int {
+	hash := SumString(r.hasher, key)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= hash
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return i
+}
+
+// removePoints removes all virtual nodes of `node`, keeping the order of the others.
+func (r *Ring) removePoints(node string) {
+	points := r.points[:0]
+	for _, p := range r.points {
+		if p.node != node {
+			points = append(points, p)
+		}
+	}
+	clear(r.points[len(points):])
+	r.points = points
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Ring_Balance(t *testing.T) {
+	var (
+		ring   = minhash.NewRing()
+		counts = make(map[string]int)
+		keys   = benchmarkKeys(100000)
+	)
+	for i := 0; i < 4; i++ {
+		if err := ring.Add("node" + strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ring.Add("heavy", 2); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		counts[ring.Get(key)]++
+	}
+	// The weight units are 6, and each member is allowed 25% away from its share.
+	for node, count := range counts {
+		want := len(keys) / 6 * ring.Weight(node)
+		if count < want*3/4 || count > want*5/4 {
+			t.Errorf("%s owns %d keys, want about %d", node, count, want)
+		}
+	}
+}
+
+func Test_Ring_Movement(t *testing.T) {
+	var (
+		ring = minhash.NewRing(minhash.RingOption{Replicas: 100})
+		keys = benchmarkKeys(10000)
+		old  = make([]string, len(keys))
+	)
+	for _, node := range []string{"a", "b", "c"} {
+		_ = ring.Add(node)
+	}
+	for i, key := range keys {
+		old[i] = ring.Get(key)
+	}
+	// Only the keys owned by the new member are moved.
+	_ = ring.Add("d")
+	var moved int
+	for i, key := range keys {
+		if owner := ring.Get(key); owner != old[i] {
+			if owner != "d" {
+				t.Fatalf("key %s moves from %s to %s", key, old[i], owner)
+			}
+			moved++
+		}
+	}
+	if moved < len(keys)/8 || moved > len(keys)*3/8 {
+		t.Errorf("%d keys are moved, want about %d", moved, len(keys)/4)
+	}
+	ring.Remove("d")
+	for i, key := range keys {
+		if ring.Get(key) != old[i] {
+			t.Fatalf("key %s is not restored after Remove", key)
+		}
+	}
+	// The placement does not depend on the order of the members.
+	other := minhash.NewRing(minhash.RingOption{Replicas: 100})
+	for _, node := range []string{"c", "a", "b"} {
+		_ = other.Add(node)
+	}
+	for i, key := range keys {
+		if other.Get(key) != old[i] {
+			t.Fatalf("key %s is placed differently by member order", key)
+		}
+	}
+}
+
+func Test_Ring_GetN(t *testing.T) {
+	ring := minhash.NewRing()
+	if ring.Get("k") != "" || ring.GetN("k", 2) != nil || ring.Len() != 0 {
+		t.Fatal("empty ring returns members")
+	}
+	for _, node := range []string{"a", "b", "c"} {
+		_ = ring.Add(node)
+	}
+	nodes := ring.GetN("k", 5)
+	if len(nodes) != 3 || nodes[0] != ring.Get("k") {
+		t.Fatalf("GetN = %v", nodes)
+	}
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if seen[node] {
+			t.Fatalf("GetN returns duplicated members %v", nodes)
+		}
+		seen[node] = true
+	}
+	if !reflect.DeepEqual(ring.Nodes(), []string{"a", "b", "c"}) || ring.Len() != 3 {
+		t.Fatalf("Nodes = %v", ring.Nodes())
+	}
+	if err := ring.Add("a", 3); err != nil || ring.Weight("a") != 3 || ring.Weight("x") != 0 {
+		t.Fatalf("Weight after update = %d, %v", ring.Weight("a"), err)
+	}
+	if ring.Add("") == nil || ring.Add("x", 0) == nil {
+		t.Fatal("Add of invalid member succeeds")
+	}
+}
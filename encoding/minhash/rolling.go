@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// rabinKarpBase is the base of the Rabin-Karp polynomial, which is the 64 bits FNV prime.
+	rabinKarpBase = 0x100000001b3
+)
+
+// rollingTable maps bytes to random 64 bits values for Buzhash and the gear hash of the chunker.
+// It is fixed, so that the hashes are identical across processes.
+var rollingTable = func() (table [256]uint64) {
+	for i := range table {
+		table[i] = murmur3Mix64(uint64(i+1) * goldenRatio64)
+	}
+	return table
+}()
+
+// RollingHash is a hash over a window of bytes, which can slide the window by one byte in constant time.
+// The window is the bytes written with Write, and Roll removes byte `out` from the start of the window
+// and appends byte `in` to the end of the window, so that the window size does not change.
+// It is the caller's responsibility to pass the byte actually at the start of the window as `out`.
+type RollingHash interface {
+	hash.Hash64
+	// Roll slides the window by removing byte `out` and appending byte `in`.
+	Roll(out, in byte)
+}
+
+// rabinKarp is the Rabin-Karp rolling hash over the ring of integers modulo 2^64.
+type rabinKarp struct {
+	hash uint64
+	pow  uint64 // Base to the power of the window size minus one.
+	size int    // Window size.
+}
+
+// buzhash is the Buzhash rolling hash, also known as cyclic polynomial.
+type buzhash struct {
+	hash uint64
+	size int // Window size.
+}
+
+// NewRabinKarp returns a new RollingHash computing the Rabin-Karp hash, which is the polynomial
+// of the bytes in the window with a fixed base.
+func NewRabinKarp() RollingHash {
+	return &rabinKarp{pow: 1}
+}
+
+// NewBuzhash returns a new RollingHash computing the Buzhash, which xors the rotated random values
+// of the bytes in the window. It has better distributed bits than the Rabin-Karp hash.
+func NewBuzhash() RollingHash {
+	return &buzhash{}
+}
+
+func (s *rabinKarp) Roll(out, in byte) {
+	s.hash = (s.hash-uint64(out)*s.pow)*rabinKarpBase + uint64(in)
+}
+
+func (s *buzhash) Roll(out, in byte) {
+	s.hash = bits.RotateLeft64(s.hash, 1) ^ bits.RotateLeft64(rollingTable[out], s.size) ^ rollingTable[in]
+}
+
+func (s *rabinKarp) Reset() { *s = rabinKarp{pow: 1} }
+func (s *buzhash) Reset()   { *s = buzhash{} }
+
+func (s *rabinKarp) Sum64() uint64 { return s.hash }
+func (s *buzhash) Sum64() uint64   { return s.hash }
+
+func (s *rabinKarp) Write(data []byte) (int, error) {
+	for _, b := range data {
+		if s.size > 0 {
+			s.pow *= rabinKarpBase
+		}
+		s.hash = s.hash*rabinKarpBase + uint64(b)
+		s.size++
+	}
+	return len(data), nil
+}
+
+func (s *buzhash) Write(data []byte) (int, error) {
+	for _, b := range data {
+		s.hash = bits.RotateLeft64(s.hash, 1) ^ rollingTable[b]
+	}
+	s.size += len(data)
+	return len(data), nil
+}
+
+func (s *rabinKarp) Size() int { return 8 }
+func (s *buzhash) Size() int   { return 8 }
+
+func (s *rabinKarp) BlockSize() int { return 1 }
+func (s *buzhash) BlockSize() int   { return 1 }
+
+func (s *rabinKarp) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, s.hash) }
+func (s *buzhash) Sum(in []byte) []byte   { return binary.BigEndian.AppendUint64(in, s.hash) }
@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_RollingHash_Roll(t *testing.T) {
+	data := make([]byte, 4096)
+	rand.New(rand.NewSource(1)).Read(data)
+	for name, newFunc := range map[string]func() minhash.RollingHash{
+		"rabin-karp": minhash.NewRabinKarp,
+		"buzhash":    minhash.NewBuzhash,
+	} {
+		for _, window := range []int{1, 16, 48, 64, 100} {
+			rolling := newFunc()
+			_, _ = rolling.Write(data[:window])
+			for i := window; i < len(data); i++ {
+				rolling.Roll(data[i-window], data[i])
+				// The rolled hash equals the hash of the window written at once.
+				fresh := newFunc()
+				_, _ = fresh.Write(data[i-window+1 : i+1])
+				if rolling.Sum64() != fresh.Sum64() {
+					t.Fatalf("%s window %d: rolled hash at %d = %#x, want %#x", name, window, i, rolling.Sum64(), fresh.Sum64())
+				}
+			}
+		}
+		h := newFunc()
+		_, _ = h.Write([]byte("abc"))
+		sum := h.Sum(nil)
+		h.Reset()
+		if h.Sum64() != 0 || len(sum) != h.Size() || h.BlockSize() != 1 {
+			t.Fatalf("%s: Reset or Size is wrong", name)
+		}
+	}
+}
+
+func Test_RabinKarp_Vector(t *testing.T) {
+	// The polynomial ((a*B + b)*B + c) mod 2^64 with the 64 bits FNV prime B.
+	h := minhash.NewRabinKarp()
+	_, _ = h.Write([]byte("abc"))
+	if h.Sum64() != 0x14a08000118b972 {
+		t.Fatalf("RabinKarp(abc) = %#x", h.Sum64())
+	}
+	// Rolling out "a" and in "d" gives the hash of "bcd".
+	h.Roll('a', 'd')
+	want := minhash.NewRabinKarp()
+	_, _ = want.Write([]byte("bcd"))
+	if h.Sum64() != want.Sum64() {
+		t.Fatalf("RabinKarp rolled = %#x, want %#x", h.Sum64(), want.Sum64())
+	}
+}
@@ -5,12 +5,29 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // RS implements the classic RS hash algorithm for 32 bits.
 func RS(str []byte) uint32 {
+	return RSSeed(0, str)
+}
+
+// RSString is the same as RS for string `str`, which hashes `str` without copying it.
+func RSString(str string) uint32 {
+	return RS(stringBytes(str))
+}
+
+// RSSeed implements the classic RS hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as RS.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func RSSeed(seed uint32, str []byte) uint32 {
 	var (
 		b    uint32 = 378551
 		a    uint32 = 63689
-		hash uint32 = 0
+		hash        = seed
 	)
 	for i := 0; i < len(str); i++ {
 		hash = hash*a + uint32(str[i])
@@ -19,12 +36,29 @@ func RS(str []byte) uint32 {
 	return hash
 }
 
+// RSSeedString is the same as RSSeed for string `str`, which hashes `str` without copying it.
+func RSSeedString(seed uint32, str string) uint32 {
+	return RSSeed(seed, stringBytes(str))
+}
+
 // RS64 implements the classic RS hash algorithm for 64 bits.
 func RS64(str []byte) uint64 {
+	return RS64Seed(0, str)
+}
+
+// RS64String is the same as RS64 for string `str`, which hashes `str` without copying it.
+func RS64String(str string) uint64 {
+	return RS64(stringBytes(str))
+}
+
+// RS64Seed implements the classic RS hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as RS64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func RS64Seed(seed uint64, str []byte) uint64 {
 	var (
 		b    uint64 = 378551
 		a    uint64 = 63689
-		hash uint64 = 0
+		hash        = seed
 	)
 	for i := 0; i < len(str); i++ {
 		hash = hash*a + uint64(str[i])
@@ -32,3 +66,65 @@ func RS64(str []byte) uint64 {
 	}
 	return hash
 }
+
+// RS64SeedString is the same as RS64Seed for string `str`, which hashes `str` without copying it.
+func RS64SeedString(seed uint64, str string) uint64 {
+	return RS64Seed(seed, stringBytes(str))
+}
+
+type rs32 struct {
+	hash uint32
+	a    uint32 // Running multiplier, advanced by 378551 for every written byte.
+}
+
+type rs64 struct {
+	hash uint64
+	a    uint64 // Running multiplier, advanced by 378551 for every written byte.
+}
+
+// NewRS32 returns a new hash.Hash32 computing the RS hash algorithm for 32 bits.
+// Its Sum32 result is identical to RS for the same written data.
+func NewRS32() hash.Hash32 {
+	return &rs32{a: 63689}
+}
+
+// NewRS64 returns a new hash.Hash64 computing the RS hash algorithm for 64 bits.
+// Its Sum64 result is identical to RS64 for the same written data.
+func NewRS64() hash.Hash64 {
+	return &rs64{a: 63689}
+}
+
+func (s *rs32) Reset() { *s = rs32{a: 63689} }
+func (s *rs64) Reset() { *s = rs64{a: 63689} }
+
+func (s *rs32) Sum32() uint32 { return s.hash }
+func (s *rs64) Sum64() uint64 { return s.hash }
+
+func (s *rs32) Write(data []byte) (int, error) {
+	hash, a := s.hash, s.a
+	for _, b := range data {
+		hash = hash*a + uint32(b)
+		a *= 378551
+	}
+	s.hash, s.a = hash, a
+	return len(data), nil
+}
+
+func (s *rs64) Write(data []byte) (int, error) {
+	hash, a := s.hash, s.a
+	for _, b := range data {
+		hash = hash*a + uint64(b)
+		a *= 378551
+	}
+	s.hash, s.a = hash, a
+	return len(data), nil
+}
+
+func (s *rs32) Size() int { return 4 }
+func (s *rs64) Size() int { return 8 }
+
+func (s *rs32) BlockSize() int { return 1 }
+func (s *rs64) BlockSize() int { return 1 }
+
+func (s *rs32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, s.hash) }
+func (s *rs64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, s.hash) }
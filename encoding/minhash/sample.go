@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"math"
+)
+
+const (
+	// sampleSeed is the fixed seed of Sample and Bucket, which must never change,
+	// so that keys are sampled and bucketed identically across services and releases.
+	sampleSeed = 0x5a3c9e1f7b2d4681
+)
+
+// Sample reports whether `key` is sampled at `rate` in range [0, 1], eg: 0.01 samples about 1% of keys.
+// It is deterministic, the same key is always sampled or not at the same rate in any process,
+// and the keys sampled at a rate are also sampled at any higher rate.
+func Sample(key string, rate float64) bool {
+	return sampleUnit(XXH64Seed(sampleSeed, stringBytes(key))) < rate
+}
+
+// Bucket returns the index of the bucket of `key` among buckets of `weights`, for splitting traffic
+// such as A/B experiments. A bucket is chosen with probability proportional to its weight,
+// and buckets of zero or invalid weights are never chosen.
+// Different `salt`, eg: the experiment name, buckets the keys independently.
+// It is deterministic, the same key is always in the same bucket for the same salt and weights
+// in any process. It returns -1 if there is no bucket of positive weight.
+func Bucket(key, salt string, weights []float64) int {
+	var total float64
+	for _, w := range weights {
+		if bucketWeightValid(w) {
+			total += w
+		}
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return -1
+	}
+	var (
+		seed      = XXH64Seed(sampleSeed, stringBytes(salt))
+		point     = sampleUnit(XXH64Seed(seed, stringBytes(key))) * total
+		last      = -1
+		cumulated float64
+	)
+	for i, w := range weights {
+		if !bucketWeightValid(w) {
+			continue
+		}
+		cumulated += w
+		if point < cumulated {
+			return i
+		}
+		last = i
+	}
+	// The point may reach the total by rounding errors.
+	return last
+}
+
+// sampleUnit maps `hash` to a float64 in range [0, 1) uniformly.
+func sampleUnit(hash uint64) float64 {
+	return float64(hash>>11) / (1 << 53)
+}
+
+// bucketWeightValid reports whether `weight` is a positive finite weight.
+func bucketWeightValid(weight float64) bool {
+	return weight > 0 && !math.IsInf(weight, 1)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Sample(t *testing.T) {
+	const n = 100000
+	for _, rate := range []float64{0, 0.001, 0.1, 0.5, 1} {
+		var sampled int
+		for i := 0; i < n; i++ {
+			key := "user:" + strconv.Itoa(i)
+			if minhash.Sample(key, rate) {
+				sampled++
+				// The keys sampled at a rate are sampled at any higher rate.
+				if !minhash.Sample(key, math.Nextafter(rate, 2)) || !minhash.Sample(key, 1) {
+					t.Fatalf("key %s is sampled at %v but not at a higher rate", key, rate)
+				}
+			}
+		}
+		// The tolerance is about 5 standard deviations of the binomial distribution.
+		if diff := math.Abs(float64(sampled) - rate*n); diff > 5*math.Sqrt(rate*(1-rate)*n)+1 {
+			t.Errorf("rate %v samples %d of %d keys", rate, sampled, n)
+		}
+	}
+}
+
+func Test_Bucket(t *testing.T) {
+	const n = 100000
+	weights := []float64{1, 0, 2, math.NaN(), 7, -1, math.Inf(1)}
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		bucket := minhash.Bucket("user:"+strconv.Itoa(i), "experiment", weights)
+		if bucket < 0 || bucket >= len(weights) {
+			t.Fatalf("Bucket = %d", bucket)
+		}
+		counts[bucket]++
+	}
+	// The invalid weights are never chosen, and the others are chosen in proportion.
+	for i, want := range []float64{0.1, 0, 0.2, 0, 0.7, 0, 0} {
+		p := want * n
+		if diff := math.Abs(float64(counts[i]) - p); diff > 5*math.Sqrt(p*(1-want))+0.5 {
+			t.Errorf("bucket %d has %d keys, want about %.0f", i, counts[i], p)
+		}
+	}
+	for _, weights := range [][]float64{nil, {0, -1}, {math.NaN()}, {math.MaxFloat64, math.MaxFloat64}} {
+		if bucket := minhash.Bucket("key", "salt", weights); bucket != -1 {
+			t.Errorf("Bucket of weights %v = %d, want -1", weights, bucket)
+		}
+	}
+}
+
+func Test_Bucket_Salt(t *testing.T) {
+	// The buckets of different salts are independent, so about a half of keys are in the same bucket of 2.
+	const n = 10000
+	var same int
+	for i := 0; i < n; i++ {
+		key := "user:" + strconv.Itoa(i)
+		if minhash.Bucket(key, "a", []float64{1, 1}) == minhash.Bucket(key, "b", []float64{1, 1}) {
+			same++
+		}
+	}
+	if same < n/2-300 || same > n/2+300 {
+		t.Fatalf("%d of %d keys are in the same bucket of different salts", same, n)
+	}
+}
+
+func Test_Bucket_Stable(t *testing.T) {
+	// The buckets must never change across releases, since services bucket the same keys independently.
+	for i, want := range []int{1, 3, 3, 3, 0, 0, 3, 0} {
+		key := "user:" + strconv.Itoa(i+1)
+		if bucket := minhash.Bucket(key, "experiment", []float64{1, 1, 1, 1}); bucket != want {
+			t.Errorf("Bucket(%s) = %d, want %d", key, bucket, want)
+		}
+		if sampled := minhash.Sample(key, 0.5); sampled != (i == 6) {
+			t.Errorf("Sample(%s, 0.5) = %v", key, sampled)
+		}
+	}
+}
@@ -5,9 +5,26 @@
 
 package minhash
 
+import (
+	"encoding/binary"
+	"hash"
+)
+
 // SDBM implements the classic SDBM hash algorithm for 32 bits.
 func SDBM(str []byte) uint32 {
-	var hash uint32
+	return SDBMSeed(0, str)
+}
+
+// SDBMString is the same as SDBM for string `str`, which hashes `str` without copying it.
+func SDBMString(str string) uint32 {
+	return SDBM(stringBytes(str))
+}
+
+// SDBMSeed implements the classic SDBM hash algorithm for 32 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as SDBM.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func SDBMSeed(seed uint32, str []byte) uint32 {
+	var hash = seed
 	for _, b := range str {
 		// equivalent to: hash = 65599*hash + uint32(b)
 		hash = uint32(b) + (hash << 6) + (hash << 16) - hash
@@ -15,12 +32,86 @@ func SDBM(str []byte) uint32 {
 	return hash
 }
 
+// SDBMSeedString is the same as SDBMSeed for string `str`, which hashes `str` without copying it.
+func SDBMSeedString(seed uint32, str string) uint32 {
+	return SDBMSeed(seed, stringBytes(str))
+}
+
 // SDBM64 implements the classic SDBM hash algorithm for 64 bits.
 func SDBM64(str []byte) uint64 {
-	var hash uint64
+	return SDBM64Seed(0, str)
+}
+
+// SDBM64String is the same as SDBM64 for string `str`, which hashes `str` without copying it.
+func SDBM64String(str string) uint64 {
+	return SDBM64(stringBytes(str))
+}
+
+// SDBM64Seed implements the classic SDBM hash algorithm for 64 bits with `seed` XORed into the initial hash value,
+// so that seed 0 gives the same digest as SDBM64.
+// The seed is not a key and gives no resistance to hash flooding, see ProcessSeed.
+func SDBM64Seed(seed uint64, str []byte) uint64 {
+	var hash = seed
 	for _, b := range str {
 		// equivalent to: hash = 65599*hash + uint64(b)
 		hash = uint64(b) + (hash << 6) + (hash << 16) - hash
 	}
 	return hash
 }
+
+// SDBM64SeedString is the same as SDBM64Seed for string `str`, which hashes `str` without copying it.
+func SDBM64SeedString(seed uint64, str string) uint64 {
+	return SDBM64Seed(seed, stringBytes(str))
+}
+
+type (
+	sdbm32 uint32
+	sdbm64 uint64
+)
+
+// NewSDBM32 returns a new hash.Hash32 computing the SDBM hash algorithm for 32 bits.
+// Its Sum32 result is identical to SDBM for the same written data.
+func NewSDBM32() hash.Hash32 {
+	var s sdbm32
+	return &s
+}
+
+// NewSDBM64 returns a new hash.Hash64 computing the SDBM hash algorithm for 64 bits.
+// Its Sum64 result is identical to SDBM64 for the same written data.
+func NewSDBM64() hash.Hash64 {
+	var s sdbm64
+	return &s
+}
+
+func (s *sdbm32) Reset() { *s = 0 }
+func (s *sdbm64) Reset() { *s = 0 }
+
+func (s *sdbm32) Sum32() uint32 { return uint32(*s) }
+func (s *sdbm64) Sum64() uint64 { return uint64(*s) }
+
+func (s *sdbm32) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash = sdbm32(b) + (hash << 6) + (hash << 16) - hash
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sdbm64) Write(data []byte) (int, error) {
+	hash := *s
+	for _, b := range data {
+		hash = sdbm64(b) + (hash << 6) + (hash << 16) - hash
+	}
+	*s = hash
+	return len(data), nil
+}
+
+func (s *sdbm32) Size() int { return 4 }
+func (s *sdbm64) Size() int { return 8 }
+
+func (s *sdbm32) BlockSize() int { return 1 }
+func (s *sdbm64) BlockSize() int { return 1 }
+
+func (s *sdbm32) Sum(in []byte) []byte { return binary.BigEndian.AppendUint32(in, uint32(*s)) }
+func (s *sdbm64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, uint64(*s)) }
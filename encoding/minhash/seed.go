@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+)
+
+var (
+	// processKeyOnce guarantees the process key is generated only once.
+	processKeyOnce sync.Once
+	// processKey is the random 128 bits key of current process.
+	processKey [2]uint64
+)
+
+// ProcessSeed returns a random seed, which is generated once and stays the same for the lifetime of current process.
+// It varies the digests of the seeded hash functions like XXH64Seed and DJBSeed between processes,
+// do not use it for hashes that are persisted or shared with other processes.
+//
+// Note that a seed is not a key and does not resist hash flooding. The classic algorithms like DJBSeed
+// are linear, keys of the same length colliding under one seed collide under any seed.
+// Use SipHash24 or SipHash13 with ProcessKey for hash tables keyed by user-controlled data.
+func ProcessSeed() uint64 {
+	k0, _ := ProcessKey()
+	return k0
+}
+
+// ProcessKey returns a random 128 bits key for SipHash24 and SipHash13, which is generated once
+// and stays the same for the lifetime of current process.
+// It is designed for hashing user-controlled keys in memory, which resists hash flooding.
+// Do not use it for hashes that are persisted or shared with other processes.
+func ProcessKey() (k0, k1 uint64) {
+	processKeyOnce.Do(func() {
+		var b [16]byte
+		if _, err := cryptorand.Read(b[:]); err != nil {
+			// The runtime seeds math/rand/v2 with system randomness as well.
+			binary.LittleEndian.PutUint64(b[:8], rand.Uint64())
+			binary.LittleEndian.PutUint64(b[8:], rand.Uint64())
+		}
+		processKey[0] = binary.LittleEndian.Uint64(b[:8])
+		processKey[1] = binary.LittleEndian.Uint64(b[8:])
+	})
+	return processKey[0], processKey[1]
+}
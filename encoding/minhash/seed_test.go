@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_Seed_Zero(t *testing.T) {
+	data := []byte("user:session:42")
+	for name, equal := range map[string]bool{
+		"AP":     minhash.APSeed(0, data) == minhash.AP(data),
+		"AP64":   minhash.AP64Seed(0, data) == minhash.AP64(data),
+		"BKDR":   minhash.BKDRSeed(0, data) == minhash.BKDR(data),
+		"BKDR64": minhash.BKDR64Seed(0, data) == minhash.BKDR64(data),
+		"DJB":    minhash.DJBSeed(0, data) == minhash.DJB(data),
+		"DJB64":  minhash.DJB64Seed(0, data) == minhash.DJB64(data),
+		"ELF":    minhash.ELFSeed(0, data) == minhash.ELF(data),
+		"ELF64":  minhash.ELF64Seed(0, data) == minhash.ELF64(data),
+		"JS":     minhash.JSSeed(0, data) == minhash.JS(data),
+		"JS64":   minhash.JS64Seed(0, data) == minhash.JS64(data),
+		"PJW":    minhash.PJWSeed(0, data) == minhash.PJW(data),
+		"PJW64":  minhash.PJW64Seed(0, data) == minhash.PJW64(data),
+		"RS":     minhash.RSSeed(0, data) == minhash.RS(data),
+		"RS64":   minhash.RS64Seed(0, data) == minhash.RS64(data),
+		"SDBM":   minhash.SDBMSeed(0, data) == minhash.SDBM(data),
+		"SDBM64": minhash.SDBM64Seed(0, data) == minhash.SDBM64(data),
+	} {
+		if !equal {
+			t.Errorf("%sSeed with seed 0 differs from %s", name, name)
+		}
+	}
+}
+
+func Test_Seed_NotKeyed(t *testing.T) {
+	// The seeds of the classic algorithms do not separate the colliding keys, which the keys of SipHash do.
+	var (
+		a      = []byte("B\x00")
+		b      = []byte("A\x83")
+		k0, k1 = minhash.ProcessKey()
+	)
+	for _, seed := range []uint32{0, 12345, 0xdeadbeef, uint32(minhash.ProcessSeed())} {
+		if minhash.BKDRSeed(seed, a) != minhash.BKDRSeed(seed, b) {
+			t.Fatalf("BKDRSeed(%d) does not collide, update the documentation of the seeds", seed)
+		}
+	}
+	if minhash.SipHash24(k0, k1, a) == minhash.SipHash24(k0, k1, b) {
+		t.Fatal("SipHash24 collides")
+	}
+}
+
+func Test_ProcessKey(t *testing.T) {
+	k0, k1 := minhash.ProcessKey()
+	if k0 == 0 && k1 == 0 {
+		t.Fatal("ProcessKey is zero")
+	}
+	if k2, k3 := minhash.ProcessKey(); k2 != k0 || k3 != k1 {
+		t.Fatal("ProcessKey changes in the process")
+	}
+	if minhash.ProcessSeed() != minhash.ProcessSeed() {
+		t.Fatal("ProcessSeed changes in the process")
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+const (
+	// defaultShards is the default number of shards of ShardedMap.
+	defaultShards = 32
+)
+
+// ShardedMap is a concurrent map split into shards, each shard is a map protected by its own lock,
+// so that operations on keys of different shards do not contend with each other.
+// The shard of a key is chosen by the digest of the key.
+// It is safe for concurrent use.
+type ShardedMap[K comparable, V any] struct {
+	hashFunc func(key K) uint64
+	shards   []shardedMapShard[K, V]
+}
+
+// ShardedMapOption is the option for creating a ShardedMap.
+type ShardedMapOption struct {
+	Hasher Hasher // Hash algorithm for choosing the shards of keys, it is xxh64 in default.
+	Shards int    // Number of shards, it is 32 in default.
+}
+
+// shardedMapShard is a shard of ShardedMap.
+type shardedMapShard[K comparable, V any] struct {
+	mu   sync.RWMutex
+	data map[K]V
+	_    [32]byte // Padding to keep the shards in different cache lines.
+}
+
+// NewShardedMap creates and returns an empty ShardedMap.
+// String keys are hashed without copying and integer keys are hashed by their little-endian bytes,
+// and keys of other types are hashed by their bytes compared by ==, in which pointers, channels and
+// the other references are hashed by their addresses, the same as Go maps compare them.
+func NewShardedMap[K comparable, V any](option ...ShardedMapOption) *ShardedMap[K, V] {
+	var (
+		hasher = defaultHasher
+		shards = defaultShards
+	)
+	if len(option) > 0 {
+		if option[0].Hasher != nil {
+			hasher = option[0].Hasher
+		}
+		if option[0].Shards > 0 {
+			shards = option[0].Shards
+		}
+	}
+	m := &ShardedMap[K, V]{
+		hashFunc: shardedMapHashFunc[K](hasher),
+		shards:   make([]shardedMapShard[K, V], shards),
+	}
+	for i := range m.shards {
+		m.shards[i].data = make(map[K]V)
+	}
+	return m
+}
+
+// Get returns the value of `key` and whether `key` exists.
+func (m *ShardedMap[K, V]) Get(key K) (value V, ok bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	value, ok = s.data[key]
+	s.mu.RUnlock()
+	return
+}
+
+// Set sets the value of `key` to `value`.
+func (m *ShardedMap[K, V]) Set(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	s.data[key] = value
+	s.mu.Unlock()
+}
+
+// GetOrSet returns the existing value of `key` if it exists, or else sets the value of `key` to `value`
+// and returns it. The `loaded` result is true if the value exists.
+func (m *ShardedMap[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	return m.GetOrSetFunc(key, func() V {
+		return value
+	})
+}
+
+// GetOrSetFunc is like GetOrSet, but it sets the value returned by `f` only if `key` does not exist.
+// The function `f` is called with the lock of the shard held, so it must not access the map.
+func (m *ShardedMap[K, V]) GetOrSetFunc(key K, f func() V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	actual, loaded = s.data[key]
+	s.mu.RUnlock()
+	if loaded {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if actual, loaded = s.data[key]; loaded {
+		return
+	}
+	actual = f()
+	s.data[key] = actual
+	return
+}
+
+// Delete deletes `key` and returns its value and whether it existed.
+func (m *ShardedMap[K, V]) Delete(key K) (value V, ok bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	if value, ok = s.data[key]; ok {
+		delete(s.data, key)
+	}
+	s.mu.Unlock()
+	return
+}
+
+// Range calls `f` for each key and value until `f` returns false.
+// It iterates over a snapshot of each shard taken under the lock of the shard, and calls `f`
+// without holding any lock, so `f` can safely modify the map. Modifications of a shard made
+// after its snapshot is taken are not visited.
+func (m *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	type entry struct {
+		key   K
+		value V
+	}
+	var entries []entry
+	for i := range m.shards {
+		s := &m.shards[i]
+		entries = entries[:0]
+		s.mu.RLock()
+		for k, v := range s.data {
+			entries = append(entries, entry{k, v})
+		}
+		s.mu.RUnlock()
+		for _, e := range entries {
+			if !f(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of keys.
+func (m *ShardedMap[K, V]) Len() int {
+	var n int
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.data)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Clear deletes all keys.
+func (m *ShardedMap[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		clear(s.data)
+		s.mu.Unlock()
+	}
+}
+
+// shard returns the shard of `key`.
+func (m *ShardedMap[K, V]) shard(key K) *shardedMapShard[K, V] {
+	return &m.shards[m.hashFunc(key)%uint64(len(m.shards))]
+}
+
+// shardedMapHashFunc returns the function hashing keys of type K by `hasher`,
+// which is chosen once by the kind of K to avoid reflection for each key.
+func shardedMapHashFunc[K comparable](hasher Hasher) func(key K) uint64 {
+	t := reflect.TypeFor[K]()
+	switch t.Kind() {
+	case reflect.String:
+		return func(key K) uint64 {
+			return SumString(hasher, *(*string)(unsafe.Pointer(&key)))
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		// The key is read as an unsigned integer of its size, which is the address for references.
+		size := t.Size()
+		return func(key K) uint64 {
+			var (
+				p = unsafe.Pointer(&key)
+				n uint64
+			)
+			switch size {
+			case 1:
+				n = uint64(*(*uint8)(p))
+			case 2:
+				n = uint64(*(*uint16)(p))
+			case 4:
+				n = uint64(*(*uint32)(p))
+			default:
+				n = *(*uint64)(p)
+			}
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], n)
+			return hasher.Sum64(buf[:])
+		}
+
+	default:
+		return func(key K) uint64 {
+			var buf [64]byte
+			return hasher.Sum64(appendComparable(buf[:0], reflect.ValueOf(&key).Elem()))
+		}
+	}
+}
+
+// appendComparable appends the bytes of comparable `v` to `buf`, which are the same for the values equal by ==.
+// References are appended by their addresses, and the dynamic values of interfaces by their types and values.
+func appendComparable(buf []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.LittleEndian.AppendUint64(buf, v.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return appendComparableFloat(buf, v.Float())
+
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return appendComparableFloat(appendComparableFloat(buf, real(c)), imag(c))
+
+	case reflect.String:
+		return appendString(buf, v.String())
+
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Pointer()))
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			buf = appendComparable(buf, v.Index(i))
+		}
+		return buf
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// Blank fields are ignored by ==.
+			if v.Type().Field(i).Name != "_" {
+				buf = appendComparable(buf, v.Field(i))
+			}
+		}
+		return buf
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(buf, 0)
+		}
+		// Values of different dynamic types are never equal, so their types are appended to separate them.
+		buf = appendString(append(buf, 1), v.Elem().Type().String())
+		return appendComparable(buf, v.Elem())
+
+	default:
+		// Uncomparable values in interfaces, which panic in comparison of Go maps.
+		return buf
+	}
+}
+
+// appendComparableFloat appends the bits of `f` to `buf`, with -0 appended as +0 as they are equal.
+func appendComparableFloat(buf []byte, f float64) []byte {
+	if f == 0 {
+		f = 0
+	}
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_ShardedMap(t *testing.T) {
+	m := minhash.NewShardedMap[string, int](minhash.ShardedMapOption{Shards: 8})
+	for i := 0; i < 1000; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	if v, ok := m.Get("42"); !ok || v != 42 || m.Len() != 1000 {
+		t.Fatalf("Get = %d, %v, Len = %d", v, ok, m.Len())
+	}
+	if v, loaded := m.GetOrSet("42", 0); !loaded || v != 42 {
+		t.Fatalf("GetOrSet of existing key = %d, %v", v, loaded)
+	}
+	if v, loaded := m.GetOrSet("new", 7); loaded || v != 7 {
+		t.Fatalf("GetOrSet of new key = %d, %v", v, loaded)
+	}
+	var called int
+	for i := 0; i < 2; i++ {
+		if v, loaded := m.GetOrSetFunc("func", func() int { called++; return 9 }); v != 9 || loaded != (i == 1) {
+			t.Fatalf("GetOrSetFunc = %d, %v", v, loaded)
+		}
+	}
+	if called != 1 {
+		t.Fatalf("GetOrSetFunc calls f %d times", called)
+	}
+	if v, ok := m.Delete("42"); !ok || v != 42 {
+		t.Fatalf("Delete = %d, %v", v, ok)
+	}
+	if _, ok := m.Delete("42"); ok {
+		t.Fatal("Delete of deleted key succeeds")
+	}
+	// The map can be modified in Range.
+	var visited int
+	m.Range(func(key string, value int) bool {
+		visited++
+		m.Delete(key)
+		return true
+	})
+	if visited != 1001 || m.Len() != 0 {
+		t.Fatalf("Range visits %d keys, Len = %d after deleting", visited, m.Len())
+	}
+	m.Set("a", 1)
+	m.Set("b", 2)
+	visited = 0
+	m.Range(func(string, int) bool { visited++; return false })
+	if visited != 1 {
+		t.Fatalf("Range does not stop, visits %d keys", visited)
+	}
+	m.Clear()
+	if m.Len() != 0 {
+		t.Fatal("Clear does not delete keys")
+	}
+}
+
+func Test_ShardedMap_Keys(t *testing.T) {
+	type key struct {
+		F float64
+		I interface{}
+		_ int
+	}
+	var (
+		m = minhash.NewShardedMap[key, string]()
+		p = new(int)
+	)
+	m.Set(key{F: math.Copysign(0, -1), I: 1}, "zero")
+	m.Set(key{I: int64(1)}, "int64")
+	m.Set(key{I: p}, "pointer")
+	// The keys equal by == are in the same shard, eg: -0 and +0.
+	for k, want := range map[key]string{{I: 1}: "zero", {I: int64(1)}: "int64", {I: p}: "pointer"} {
+		if v, ok := m.Get(k); !ok || v != want {
+			t.Errorf("Get(%v) = %q, %v, want %q", k, v, ok, want)
+		}
+	}
+	if _, ok := m.Get(key{I: new(int)}); ok {
+		t.Error("pointer key is found by another pointer")
+	}
+	ints := minhash.NewShardedMap[int8, int]()
+	for i := -128; i < 128; i++ {
+		ints.Set(int8(i), i)
+	}
+	if v, ok := ints.Get(-1); !ok || v != -1 || ints.Len() != 256 {
+		t.Fatalf("Get(-1) = %d, %v", v, ok)
+	}
+}
+
+func Test_ShardedMap_Concurrent(t *testing.T) {
+	var (
+		m  = minhash.NewShardedMap[int, int]()
+		wg sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := g*1000 + i
+				m.Set(key, key)
+				if v, ok := m.Get(key); !ok || v != key {
+					t.Errorf("Get(%d) = %d, %v", key, v, ok)
+					return
+				}
+				if i%2 == 0 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Len() != 4000 {
+		t.Fatalf("Len = %d, want 4000", m.Len())
+	}
+}
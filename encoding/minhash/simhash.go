@@ -0,0 +1,43 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"math/bits"
+)
+
+// SimHash returns the 64 bits SimHash fingerprint of `features`, in which similar feature sets
+// have fingerprints of small Hamming distance. Duplicated features weigh more.
+// The features are hashed by optional `hasher`, which is xxh64 in default and should be a 64 bits algorithm.
+func SimHash(features [][]byte, hasher ...Hasher) uint64 {
+	h := defaultHasher
+	if len(hasher) > 0 && hasher[0] != nil {
+		h = hasher[0]
+	}
+	var votes [64]int
+	for _, feature := range features {
+		hash := h.Sum64(feature)
+		for i := range votes {
+			if hash&(1<<i) != 0 {
+				votes[i]++
+			} else {
+				votes[i]--
+			}
+		}
+	}
+	var fingerprint uint64
+	for i, vote := range votes {
+		if vote > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance returns the number of different bits between fingerprints `a` and `b`.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
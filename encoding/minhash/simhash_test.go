@@ -0,0 +1,36 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_SimHash(t *testing.T) {
+	var (
+		base    = minhash.SimHash(tokenRange(0, 200))
+		near    = minhash.SimHash(tokenRange(5, 205))
+		distant = minhash.SimHash(tokenRange(1000, 1200))
+	)
+	if d := minhash.HammingDistance(base, near); d > 10 {
+		t.Errorf("distance of similar features = %d", d)
+	}
+	if d := minhash.HammingDistance(base, distant); d < 16 {
+		t.Errorf("distance of different features = %d", d)
+	}
+	if minhash.SimHash(tokenRange(0, 200)) != base || minhash.SimHash(nil) != 0 {
+		t.Fatal("SimHash is not deterministic")
+	}
+	fnv, _ := minhash.Lookup("fnv1a64")
+	if minhash.SimHash(tokenRange(0, 200), fnv) == base {
+		t.Fatal("the hasher is not used")
+	}
+	if minhash.HammingDistance(0, 0xff00) != 8 {
+		t.Fatal("HammingDistance is wrong")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// SipHash24 implements the SipHash-2-4 keyed hash algorithm for 64 bits with the 128 bits key `k0` and `k1`.
+// It is designed to resist hash flooding, use it for hash tables keyed by user-controlled data.
+func SipHash24(k0, k1 uint64, str []byte) uint64 {
+	return sipHash(2, 4, k0, k1, str)
+}
+
+// SipHash24String is the same as SipHash24 for string `str`, which hashes `str` without copying it.
+func SipHash24String(k0, k1 uint64, str string) uint64 {
+	return SipHash24(k0, k1, stringBytes(str))
+}
+
+// SipHash13 implements the SipHash-1-3 keyed hash algorithm for 64 bits with the 128 bits key `k0` and `k1`.
+// It is faster than SipHash24 with a smaller security margin, which is still considered safe for hash tables.
+func SipHash13(k0, k1 uint64, str []byte) uint64 {
+	return sipHash(1, 3, k0, k1, str)
+}
+
+// SipHash13String is the same as SipHash13 for string `str`, which hashes `str` without copying it.
+func SipHash13String(k0, k1 uint64, str string) uint64 {
+	return SipHash13(k0, k1, stringBytes(str))
+}
+
+// sipHash implements SipHash-c-d with `cRounds` compression rounds and `dRounds` finalization rounds.
+func sipHash(cRounds, dRounds int, k0, k1 uint64, str []byte) uint64 {
+	var (
+		v0     = k0 ^ 0x736f6d6570736575
+		v1     = k1 ^ 0x646f72616e646f6d
+		v2     = k0 ^ 0x6c7967656e657261
+		v3     = k1 ^ 0x7465646279746573
+		length = uint64(len(str))
+	)
+	for ; len(str) >= 8; str = str[8:] {
+		m := binary.LittleEndian.Uint64(str)
+		v3 ^= m
+		for i := 0; i < cRounds; i++ {
+			v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+		}
+		v0 ^= m
+	}
+
+	// The last block holds the remaining bytes and the length in its most significant byte.
+	m := length << 56
+	for i := len(str) - 1; i >= 0; i-- {
+		m |= uint64(str[i]) << (8 * uint(i))
+	}
+	v3 ^= m
+	for i := 0; i < cRounds; i++ {
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	}
+	v0 ^= m
+
+	v2 ^= 0xff
+	for i := 0; i < dRounds; i++ {
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// sipRound is the ARX round function of SipHash.
+func sipRound(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
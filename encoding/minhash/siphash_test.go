@@ -0,0 +1,96 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+const (
+	// sipK0 and sipK1 are the key 00 01 ... 0f of the SipHash vectors.
+	sipK0 uint64 = 0x0706050403020100
+	sipK1 uint64 = 0x0f0e0d0c0b0a0908
+)
+
+// sipInput returns the message 00 01 ... of `n` bytes of the SipHash vectors.
+func sipInput(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func Test_SipHash24_Vectors(t *testing.T) {
+	// The vectors of the SipHash paper and its reference implementation.
+	for _, v := range []struct {
+		length int
+		want   uint64
+	}{
+		{0, 0x726fdb47dd0e0e31},
+		{1, 0x74f839c593dc67fd},
+		{3, 0x85676696d7fb7e2d},
+		{4, 0xcf2794e0277187b7},
+		{7, 0xab0200f58b01d137},
+		{8, 0x93f5f5799a932462},
+		{9, 0x9e0082df0ba9e4b0},
+		{15, 0xa129ca6149be45e5},
+		{16, 0x3f2acc7f57c29bdb},
+		{17, 0x699ae9f52cbe4794},
+		{63, 0x958a324ceb064572},
+	} {
+		if got := minhash.SipHash24(sipK0, sipK1, sipInput(v.length)); got != v.want {
+			t.Errorf("SipHash24 of %d bytes = %#x, want %#x", v.length, got, v.want)
+		}
+	}
+	// The vectors with the zero key.
+	for _, v := range []struct {
+		data []byte
+		want uint64
+	}{
+		{nil, 0x1e924b9d737700d7},
+		{[]byte("Hello world"), 0xc9e8a3021f3822d9},
+		{[]byte("12345678123"), 0x0f95d77ccdb0649f},
+		{make([]byte, 8), 0xe849e8bb6ffe2567},
+		{make([]byte, 1535), 0xe74d1c0ab64b2afa},
+	} {
+		if got := minhash.SipHash24(0, 0, v.data); got != v.want {
+			t.Errorf("SipHash24 of %q = %#x, want %#x", v.data, got, v.want)
+		}
+	}
+}
+
+func Test_SipHash13_Vectors(t *testing.T) {
+	// The vectors of SipHasher13 of the Rust standard library.
+	for _, v := range []struct {
+		length int
+		want   uint64
+	}{
+		{0, 0xabac0158050fc4dc},
+		{1, 0xc9f49bf37d57ca93},
+		{3, 0x8bf80ab8e7ddf7fb},
+		{4, 0xcf75576088d38328},
+		{7, 0xd3927d989bb11140},
+		{8, 0x369095118d299a8e},
+		{9, 0x25a48eb36c063de4},
+		{15, 0xd320d86d2a519956},
+		{16, 0xcc4fdd1a7d908b66},
+		{17, 0x9cf2689063dbd80c},
+		{63, 0x9d199062b7bbb3a8},
+	} {
+		if got := minhash.SipHash13(sipK0, sipK1, sipInput(v.length)); got != v.want {
+			t.Errorf("SipHash13 of %d bytes = %#x, want %#x", v.length, got, v.want)
+		}
+	}
+	if got := minhash.SipHash13(0, 0, nil); got != 0xd1fba762150c532c {
+		t.Errorf("SipHash13 of empty message = %#x, want 0xd1fba762150c532c", got)
+	}
+	if got := minhash.SipHash13String(0, 0, "Hello world"); got != 0xb11d2c66e8459107 {
+		t.Errorf("SipHash13 of %q = %#x, want 0xb11d2c66e8459107", "Hello world", got)
+	}
+}
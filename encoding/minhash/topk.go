@@ -0,0 +1,283 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// topKMagic is the magic of the binary format of TopK.
+	topKMagic = "MTPK"
+	// topKCapacityFactor is the default number of monitored keys for each of the top k keys.
+	topKCapacityFactor = 8
+)
+
+// TopK tracks the heavy hitters of a stream with the Space-Saving algorithm, monitoring
+// a fixed number of keys. Any key whose count is larger than Total/capacity is guaranteed
+// to be monitored, and the count of a monitored key is overestimated by at most its Error.
+// It is not safe for concurrent use.
+type TopK struct {
+	k        int                  // Number of keys returned by List.
+	capacity int                  // Number of monitored keys.
+	items    map[string]*topKSlot // Monitored keys.
+	heap     topKHeap             // Monitored keys ordered by ascending count.
+	total    uint64               // Total count of all added keys.
+}
+
+// TopKItem is a monitored key of TopK.
+type TopKItem struct {
+	Key   string // The key.
+	Count uint64 // Estimated count, which is never less than the real count.
+	Error uint64 // Maximum overestimation of Count.
+}
+
+// topKSlot is a monitored key in the heap.
+type topKSlot struct {
+	TopKItem
+	index int // Index in the heap.
+}
+
+// topKHeap is a min-heap of monitored keys by count.
+type topKHeap []*topKSlot
+
+// NewTopK creates and returns a TopK tracking the `k` most frequent keys.
+// The optional `capacity` is the number of monitored keys, which is 8*k in default and at least k.
+// A larger capacity gives more accurate results with more memory.
+func NewTopK(k int, capacity ...int) (*TopK, error) {
+	c := k * topKCapacityFactor
+	if len(capacity) > 0 {
+		c = capacity[0]
+	}
+	if k <= 0 || c < k {
+		return nil, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid top-k size %d with capacity %d", k, c)
+	}
+	return &TopK{
+		k:        k,
+		capacity: c,
+		items:    make(map[string]*topKSlot, c),
+	}, nil
+}
+
+// Add adds `key` to the tracker with optional `count`, which is 1 in default.
+func (t *TopK) Add(key string, count ...uint64) {
+	c := uint64(1)
+	if len(count) > 0 {
+		c = count[0]
+	}
+	t.total += c
+	if slot, ok := t.items[key]; ok {
+		slot.Count += c
+		heap.Fix(&t.heap, slot.index)
+		return
+	}
+	if len(t.heap) < t.capacity {
+		slot := &topKSlot{TopKItem: TopKItem{Key: key, Count: c}}
+		t.items[key] = slot
+		heap.Push(&t.heap, slot)
+		return
+	}
+	// Replace the key with the minimum count, which bounds the error of the new key.
+	slot := t.heap[0]
+	delete(t.items, slot.Key)
+	slot.Key, slot.Error, slot.Count = key, slot.Count, slot.Count+c
+	t.items[key] = slot
+	heap.Fix(&t.heap, 0)
+}
+
+// List returns at most k monitored keys in descending order of their counts.
+func (t *TopK) List() []TopKItem {
+	items := t.sorted()
+	if len(items) > t.k {
+		items = items[:t.k]
+	}
+	return items
+}
+
+// Get returns the monitored `key` and whether it is monitored.
+func (t *TopK) Get(key string) (TopKItem, bool) {
+	if slot, ok := t.items[key]; ok {
+		return slot.TopKItem, true
+	}
+	return TopKItem{}, false
+}
+
+// Total returns the total count of all added keys.
+func (t *TopK) Total() uint64 {
+	return t.total
+}
+
+// K returns the number of keys returned by List.
+func (t *TopK) K() int {
+	return t.k
+}
+
+// Merge merges `other` into the tracker, then the tracker tracks the keys of both trackers.
+// It is used to combine trackers of different instances, and the merged counts and errors
+// keep the guarantees of Space-Saving.
+// The keys are compared as they are, as they are not hashed by the trackers.
+// It returns an error if the trackers do not have the same k and capacity.
+func (t *TopK) Merge(other *TopK) error {
+	if other == nil || t.k != other.k || t.capacity != other.capacity {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "incompatible top-k trackers")
+	}
+	var (
+		min1   = t.minCount()
+		min2   = other.minCount()
+		merged = make(map[string]TopKItem, len(t.items)+len(other.items))
+	)
+	// A key not monitored by a full tracker may have a count up to its minimum count.
+	for key, slot := range t.items {
+		item := slot.TopKItem
+		if o, ok := other.items[key]; ok {
+			item.Count += o.Count
+			item.Error += o.Error
+		} else {
+			item.Count += min2
+			item.Error += min2
+		}
+		merged[key] = item
+	}
+	for key, slot := range other.items {
+		if _, ok := t.items[key]; !ok {
+			item := slot.TopKItem
+			item.Count += min1
+			item.Error += min1
+			merged[key] = item
+		}
+	}
+	items := make([]TopKItem, 0, len(merged))
+	for _, item := range merged {
+		items = append(items, item)
+	}
+	sortTopKItems(items)
+	if len(items) > t.capacity {
+		items = items[:t.capacity]
+	}
+	t.load(items, t.total+other.total)
+	return nil
+}
+
+// Reset removes all keys from the tracker.
+func (t *TopK) Reset() {
+	t.load(nil, 0)
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler.
+func (t *TopK) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(nil, topKMagic, nil)
+	buf = binary.AppendUvarint(buf, uint64(t.k))
+	buf = binary.AppendUvarint(buf, uint64(t.capacity))
+	buf = binary.AppendUvarint(buf, t.total)
+	buf = binary.AppendUvarint(buf, uint64(len(t.heap)))
+	for _, item := range t.sorted() {
+		buf = appendString(buf, item.Key)
+		buf = binary.AppendUvarint(buf, item.Count)
+		buf = binary.AppendUvarint(buf, item.Error)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler.
+func (t *TopK) UnmarshalBinary(data []byte) error {
+	r, _, err := newMarshalReader(data, topKMagic, true)
+	if err != nil {
+		return err
+	}
+	k, capacity, total, n := r.uvarint(), r.uvarint(), r.uvarint(), r.uvarint()
+	if r.err != nil {
+		return r.err
+	}
+	// Each item takes at least three bytes.
+	if k == 0 || capacity < k || capacity > math.MaxInt32 || n > capacity || n > uint64(len(r.data))/3 {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "invalid binary data for top-k tracker")
+	}
+	items := make([]TopKItem, n)
+	for i := range items {
+		items[i] = TopKItem{Key: r.string(), Count: r.uvarint(), Error: r.uvarint()}
+	}
+	if err = r.done(); err != nil {
+		return err
+	}
+	result := &TopK{k: int(k), capacity: int(capacity)}
+	result.load(items, total)
+	if len(result.items) != len(items) {
+		return minerror.NewCode(mincode.CodeInvalidParameter, "duplicated keys in binary data for top-k tracker")
+	}
+	*t = *result
+	return nil
+}
+
+// sorted returns all monitored keys in descending order of their counts.
+func (t *TopK) sorted() []TopKItem {
+	items := make([]TopKItem, len(t.heap))
+	for i, slot := range t.heap {
+		items[i] = slot.TopKItem
+	}
+	sortTopKItems(items)
+	return items
+}
+
+// minCount returns the minimum count of the monitored keys if the tracker is full, or else 0.
+func (t *TopK) minCount() uint64 {
+	if len(t.heap) < t.capacity || len(t.heap) == 0 {
+		return 0
+	}
+	return t.heap[0].Count
+}
+
+// load replaces the monitored keys with `items` and the total count with `total`.
+func (t *TopK) load(items []TopKItem, total uint64) {
+	t.items = make(map[string]*topKSlot, len(items))
+	t.heap = make(topKHeap, 0, len(items))
+	t.total = total
+	for _, item := range items {
+		slot := &topKSlot{TopKItem: item, index: len(t.heap)}
+		t.items[item.Key] = slot
+		t.heap = append(t.heap, slot)
+	}
+	heap.Init(&t.heap)
+}
+
+// sortTopKItems sorts `items` in descending order of their counts, then ascending order of their keys.
+func sortTopKItems(items []TopKItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Key < items[j].Key
+	})
+}
+
+func (h topKHeap) Len() int { return len(h) }
+
+func (h topKHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+
+func (h topKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap) Push(x interface{}) {
+	slot := x.(*topKSlot)
+	slot.index = len(*h)
+	*h = append(*h, slot)
+}
+
+func (h *topKHeap) Pop() interface{} {
+	old := *h
+	slot := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return slot
+}
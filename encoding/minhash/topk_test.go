@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// checkTopKBounds checks the guarantees of Space-Saving of `topk` for the real `counts`.
+func checkTopKBounds(t *testing.T, name string, topk *minhash.TopK, counts map[string]uint64, capacity int) {
+	t.Helper()
+	var total uint64
+	for _, count := range counts {
+		total += count
+	}
+	if topk.Total() != total {
+		t.Fatalf("%s: Total = %d, want %d", name, topk.Total(), total)
+	}
+	for key, count := range counts {
+		item, ok := topk.Get(key)
+		if !ok {
+			if count > total/uint64(capacity) {
+				t.Fatalf("%s: key %s of count %d > Total/capacity is not monitored", name, key, count)
+			}
+			continue
+		}
+		if item.Count < count || item.Count-item.Error > count {
+			t.Fatalf("%s: %s = %d with error %d, real count %d", name, key, item.Count, item.Error, count)
+		}
+	}
+}
+
+func Test_TopK_ErrorBound(t *testing.T) {
+	stream, counts := zipfKeys(100000, 10000, 4)
+	topk, err := minhash.NewTopK(10, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range stream {
+		topk.Add(key)
+	}
+	checkTopKBounds(t, "add", topk, counts, 100)
+	if list := topk.List(); len(list) != 10 || list[0].Key != "key:0" {
+		t.Fatalf("List = %v", list)
+	}
+}
+
+func Test_TopK_Merge(t *testing.T) {
+	var (
+		stream1, counts1 = zipfKeys(50000, 10000, 5)
+		stream2, counts2 = zipfKeys(50000, 10000, 6)
+		counts           = make(map[string]uint64)
+		topk1, _         = minhash.NewTopK(10, 100)
+		topk2, _         = minhash.NewTopK(10, 100)
+	)
+	// The second stream has different heavy hitters.
+	for i, key := range stream2 {
+		stream2[i] = "other" + key
+	}
+	for key, count := range counts1 {
+		counts[key] += count
+	}
+	for key, count := range counts2 {
+		counts["other"+key] += count
+	}
+	for _, key := range stream1 {
+		topk1.Add(key)
+	}
+	for _, key := range stream2 {
+		topk2.Add(key)
+	}
+	// The keys shared by the streams.
+	for i := 0; i < 1000; i++ {
+		topk1.Add("shared", 3)
+		topk2.Add("shared", 2)
+	}
+	counts["shared"] = 5000
+	if err := topk1.Merge(topk2); err != nil {
+		t.Fatal(err)
+	}
+	checkTopKBounds(t, "merge", topk1, counts, 100)
+	if item, ok := topk1.Get("shared"); !ok || item.Count < 5000 {
+		t.Fatalf("shared = %v, %v", item, ok)
+	}
+	for _, other := range []*minhash.TopK{
+		nil,
+		func() *minhash.TopK { topk, _ := minhash.NewTopK(10, 50); return topk }(),
+		func() *minhash.TopK { topk, _ := minhash.NewTopK(5, 100); return topk }(),
+	} {
+		if topk1.Merge(other) == nil {
+			t.Error("Merge of incompatible tracker succeeds")
+		}
+	}
+}
+
+func Test_TopK_Marshal(t *testing.T) {
+	stream, _ := zipfKeys(10000, 1000, 7)
+	topk, _ := minhash.NewTopK(5)
+	for _, key := range stream {
+		topk.Add(key)
+	}
+	data, err := topk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result minhash.TopK
+	if err = result.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if result.K() != 5 || result.Total() != topk.Total() || !reflect.DeepEqual(result.List(), topk.List()) {
+		t.Fatalf("UnmarshalBinary = %v, want %v", result.List(), topk.List())
+	}
+	if result.UnmarshalBinary(data[:len(data)-1]) == nil {
+		t.Fatal("UnmarshalBinary of truncated data succeeds")
+	}
+	if _, err = minhash.NewTopK(10, 5); err == nil {
+		t.Fatal("NewTopK with capacity less than k succeeds")
+	}
+}
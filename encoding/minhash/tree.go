@@ -0,0 +1,124 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// defaultTreeChunkSize is the default chunk size of TreeHash.
+	defaultTreeChunkSize = 1 << 20
+)
+
+// TreeOption is the option for TreeHash.
+type TreeOption struct {
+	Hasher    Hasher // 64 bits hash algorithm, it is xxh64 in default.
+	ChunkSize int64  // Size of each chunk, it is 1MiB in default.
+	Parallel  int    // Number of chunks hashed concurrently, it is the number of CPUs in default.
+}
+
+// TreeHash returns the root digest of the first `size` bytes of `reader` in tree hashing mode.
+// The data is split into chunks of fixed size which are hashed concurrently, and the root digest
+// is the hash of the size, the chunk size and the digests of all chunks in order.
+// The root digest only depends on the data, the hasher and the chunk size, but not the parallelism,
+// and it differs from the digest of hashing the data directly.
+func TreeHash(reader io.ReaderAt, size int64, option ...TreeOption) (uint64, error) {
+	var (
+		hasher    = defaultHasher
+		chunkSize = int64(defaultTreeChunkSize)
+		parallel  = runtime.NumCPU()
+	)
+	if len(option) > 0 {
+		if option[0].Hasher != nil {
+			hasher = option[0].Hasher
+		}
+		if option[0].ChunkSize > 0 {
+			chunkSize = option[0].ChunkSize
+		}
+		if option[0].Parallel > 0 {
+			parallel = option[0].Parallel
+		}
+	}
+	if hasher.Size() != 8 {
+		return 0, minerror.NewCodef(
+			mincode.CodeInvalidParameter, `tree hashing requires a 64 bits hasher, but "%s" is not`, hasher.Name(),
+		)
+	}
+	if size < 0 {
+		return 0, minerror.NewCodef(mincode.CodeInvalidParameter, "invalid tree hashing size %d", size)
+	}
+	digests, err := treeChunkDigests(reader, size, chunkSize, parallel, hasher)
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 0, 16+8*len(digests))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(size))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(chunkSize))
+	for _, d := range digests {
+		buf = binary.LittleEndian.AppendUint64(buf, d)
+	}
+	return hasher.Sum64(buf), nil
+}
+
+// treeChunkDigests hashes the chunks of `reader` with a pool of `parallel` workers,
+// and returns the digests of the chunks in order. It stops at the first error.
+func treeChunkDigests(reader io.ReaderAt, size, chunkSize int64, parallel int, hasher Hasher) ([]uint64, error) {
+	var (
+		chunks  = int((size + chunkSize - 1) / chunkSize)
+		digests = make([]uint64, chunks)
+		indexes = make(chan int)
+		done    = make(chan struct{})
+		once    sync.Once
+		wg      sync.WaitGroup
+		err     error
+	)
+	for w := 0; w < min(parallel, chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, min(chunkSize, size))
+			for i := range indexes {
+				var (
+					offset = int64(i) * chunkSize
+					data   = buf[:min(chunkSize, size-offset)]
+				)
+				n, readErr := reader.ReadAt(data, offset)
+				if n < len(data) {
+					if readErr == nil || readErr == io.EOF {
+						readErr = io.ErrUnexpectedEOF
+					}
+					once.Do(func() {
+						err = minerror.Wrapf(readErr, "read chunk at offset %d failed", offset)
+						close(done)
+					})
+					continue
+				}
+				digests[i] = hasher.Sum64(data)
+			}
+		}()
+	}
+dispatch:
+	for i := 0; i < chunks; i++ {
+		select {
+		case indexes <- i:
+		case <-done:
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
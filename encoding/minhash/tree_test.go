@@ -0,0 +1,106 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// treeRoot computes the root digest of `data` in tree hashing mode sequentially.
+func treeRoot(hasher minhash.Hasher, data []byte, chunkSize int) uint64 {
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(len(data)))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(chunkSize))
+	for i := 0; i < len(data); i += chunkSize {
+		buf = binary.LittleEndian.AppendUint64(buf, hasher.Sum64(data[i:min(i+chunkSize, len(data))]))
+	}
+	return hasher.Sum64(buf)
+}
+
+func Test_TreeHash(t *testing.T) {
+	data := chunkData(1<<20+17, 6)
+	for _, name := range []string{"xxh64", "xxh3", "fnv1a64", "crc64ecma"} {
+		hasher, err := minhash.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, chunkSize := range []int{1, 4096, 1 << 16, 1 << 20, 4 << 20} {
+			input := data
+			if chunkSize == 1 {
+				input = data[:1000]
+			}
+			want := treeRoot(hasher, input, chunkSize)
+			// The root digest does not depend on the parallelism.
+			for _, parallel := range []int{1, 3, 16} {
+				root, err := minhash.TreeHash(bytes.NewReader(input), int64(len(input)), minhash.TreeOption{
+					Hasher: hasher, ChunkSize: int64(chunkSize), Parallel: parallel,
+				})
+				if err != nil || root != want {
+					t.Fatalf("%s chunk %d parallel %d: TreeHash = %#x, %v, want %#x", name, chunkSize, parallel, root, err, want)
+				}
+			}
+		}
+	}
+}
+
+func Test_TreeHash_Default(t *testing.T) {
+	var (
+		data   = chunkData(3<<20, 7)
+		xxh, _ = minhash.Lookup("xxh64")
+	)
+	root, err := minhash.TreeHash(bytes.NewReader(data), int64(len(data)))
+	if err != nil || root != treeRoot(xxh, data, 1<<20) {
+		t.Fatalf("TreeHash = %#x, %v", root, err)
+	}
+	// Only the first size bytes are hashed.
+	prefix, err := minhash.TreeHash(bytes.NewReader(data), 1000)
+	if err != nil || prefix != treeRoot(xxh, data[:1000], 1<<20) {
+		t.Fatalf("TreeHash of prefix = %#x, %v", prefix, err)
+	}
+	empty, err := minhash.TreeHash(bytes.NewReader(nil), 0)
+	if err != nil || empty != treeRoot(xxh, nil, 1<<20) {
+		t.Fatalf("TreeHash of empty data = %#x, %v", empty, err)
+	}
+}
+
+// failingReaderAt fails to read at offsets from `offset`.
+type failingReaderAt struct {
+	io.ReaderAt
+	offset int64
+	err    error
+}
+
+func (r failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.offset {
+		return 0, r.err
+	}
+	return r.ReaderAt.ReadAt(p, off)
+}
+
+func Test_TreeHash_Invalid(t *testing.T) {
+	data := chunkData(1<<16, 8)
+	option := minhash.TreeOption{ChunkSize: 1024, Parallel: 4}
+	if _, err := minhash.TreeHash(bytes.NewReader(data), int64(len(data))+1, option); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("TreeHash beyond the data = %v", err)
+	}
+	failure := errors.New("failure")
+	reader := failingReaderAt{ReaderAt: bytes.NewReader(data), offset: 30000, err: failure}
+	if _, err := minhash.TreeHash(reader, int64(len(data)), option); !errors.Is(err, failure) {
+		t.Errorf("TreeHash = %v, want the error of the reader", err)
+	}
+	if _, err := minhash.TreeHash(bytes.NewReader(data), -1); err == nil {
+		t.Error("TreeHash of negative size succeeds")
+	}
+	fnv32, _ := minhash.Lookup("fnv1a32")
+	if _, err := minhash.TreeHash(bytes.NewReader(data), 10, minhash.TreeOption{Hasher: fnv32}); err == nil {
+		t.Error("TreeHash with 32 bits hasher succeeds")
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"unsafe"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/internal/reflection"
+)
+
+const (
+	// valueTagName is the struct tag name for Value, fields tagged with `hash:"-"` are skipped.
+	valueTagName = "hash"
+)
+
+// Tags of the encoded values for Value, which separate values of different kinds.
+const (
+	valueTagNil byte = iota
+	valueTagFalse
+	valueTagTrue
+	valueTagInt
+	valueTagUint
+	valueTagFloat
+	valueTagComplex
+	valueTagString
+	valueTagBytes
+	valueTagList
+	valueTagMap
+	valueTagStruct
+	valueTagBinary
+	valueTagCycle
+	valueTagType
+)
+
+// binaryMarshalerType is the reflection type of encoding.BinaryMarshaler.
+var binaryMarshalerType = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+
+// valueEncoder encodes values into a streaming hash.
+type valueEncoder struct {
+	writer   io.Writer
+	scratch  []byte             // Scratch buffer for encoding numbers.
+	visiting map[valueVisit]int // Depths of the references being visited, for detecting cycles.
+}
+
+// valueVisit identifies a reference being visited.
+type valueVisit struct {
+	ptr    uintptr
+	length int
+	typ    reflect.Type
+}
+
+// Value returns the structural hash of `value`, which is identical for equal values across processes.
+// It walks value recursively: pointers are followed, map entries are sorted by their encoded keys and values,
+// struct fields tagged with `hash:"-"` are skipped and cycles are encoded as back references.
+// Integers of different sizes are equal if they have the same value, and so are floats.
+// Values implementing encoding.BinaryMarshaler are hashed by their binary form, including the values whose
+// pointers implement it, so that a value is hashed the same wherever it is and as its pointer.
+// The value is encoded into the streaming hash of optional `hasher`, which is xxh64 in default.
+// It returns an error if value contains functions, channels or unsafe pointers.
+func Value(value interface{}, hasher ...Hasher) (uint64, error) {
+	h := defaultHasher
+	if len(hasher) > 0 && hasher[0] != nil {
+		h = hasher[0]
+	}
+	var (
+		writer = h.New()
+		e      = &valueEncoder{
+			writer:   writer,
+			visiting: make(map[valueVisit]int),
+		}
+	)
+	if err := e.encode(reflection.OriginValueAndKind(value).InputValue); err != nil {
+		return 0, err
+	}
+	// The digest is big-endian for both 32 and 64 bits algorithms.
+	var sum uint64
+	for _, b := range writer.Sum(nil) {
+		sum = sum<<8 | uint64(b)
+	}
+	return sum, nil
+}
+
+// encode encodes `v` into the hash.
+func (e *valueEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		return e.writeTag(valueTagNil)
+	}
+	if !v.CanInterface() && v.CanAddr() {
+		// Values of unexported fields are accessed by their addresses, so that their methods like MarshalBinary
+		// of time.Time are called instead of walking their internal fields.
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+	if !v.CanAddr() && v.CanInterface() && (v.Kind() == reflect.Struct || v.Kind() == reflect.Array ||
+		reflect.PointerTo(v.Type()).Implements(binaryMarshalerType)) {
+		// The values which are not addressable, eg: the root, map entries and values in interfaces, are copied
+		// to be addressable, so that their unexported fields are accessible by addresses, and the methods of
+		// pointer receivers are called the same as for addressable values.
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		v = copied
+	}
+	if ok, err := e.encodeMarshaler(v); ok || err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return e.writeTag(valueTagTrue)
+		}
+		return e.writeTag(valueTagFalse)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.writeUint(valueTagInt, uint64(v.Int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.writeUint(valueTagUint, v.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return e.writeUint(valueTagFloat, valueFloatBits(v.Float()))
+
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		if err := e.writeUint(valueTagComplex, valueFloatBits(real(c))); err != nil {
+			return err
+		}
+		return e.writeUint(valueTagComplex, valueFloatBits(imag(c)))
+
+	case reflect.String:
+		return e.writeBytes(valueTagString, stringBytes(v.String()))
+
+	case reflect.Interface:
+		return e.encode(v.Elem())
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return e.writeTag(valueTagNil)
+		}
+		return e.visit(v, 0, func() error {
+			return e.encode(v.Elem())
+		})
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return e.writeTag(valueTagNil)
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return e.writeBytes(valueTagBytes, v.Bytes())
+		}
+		return e.visit(v, v.Len(), func() error {
+			return e.encodeList(v)
+		})
+
+	case reflect.Array:
+		return e.encodeList(v)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return e.writeTag(valueTagNil)
+		}
+		return e.visit(v, 0, func() error {
+			return e.encodeMap(v)
+		})
+
+	case reflect.Struct:
+		return e.encodeStruct(v)
+
+	default:
+		return minerror.NewCodef(mincode.CodeNotSupported, `unsupported type "%s" for value hashing`, v.Type())
+	}
+}
+
+// encodeMarshaler encodes `v` by its binary form if it or its pointer implements encoding.BinaryMarshaler,
+// it returns false if they do not.
+func (e *valueEncoder) encodeMarshaler(v reflect.Value) (bool, error) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return false, nil
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() && !v.Type().Implements(binaryMarshalerType) &&
+		reflect.PointerTo(v.Type()).Implements(binaryMarshalerType) {
+		// The method of pointer receiver is called with the address of `v`.
+		v = v.Addr()
+	}
+	i, ok := reflection.ValueToInterface(v)
+	if !ok {
+		return false, nil
+	}
+	marshaler, ok := i.(encoding.BinaryMarshaler)
+	if !ok {
+		return false, nil
+	}
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		return true, minerror.Wrapf(err, `marshal value of type "%s" failed`, v.Type())
+	}
+	return true, e.writeBytes(valueTagBinary, data)
+}
+
+// encodeList encodes the elements of slice or array `v`.
+func (e *valueEncoder) encodeList(v reflect.Value) error {
+	if err := e.writeUint(valueTagList, uint64(v.Len())); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeMap encodes the entries of map `v` in ascending order of their encoded keys and values.
+// The dynamic types of interface keys are encoded, as the keys of different types are different keys,
+// eg: int8(1) and int64(1) of map[any]string.
+func (e *valueEncoder) encodeMap(v reflect.Value) error {
+	type entry struct {
+		key   []byte
+		value []byte
+	}
+	var (
+		entries   = make([]entry, 0, v.Len())
+		buffer    bytes.Buffer
+		encoder   = &valueEncoder{writer: &buffer, visiting: e.visiting}
+		typedKeys = v.Type().Key().Kind() == reflect.Interface
+	)
+	// encodeBytes returns the encoded bytes of `f`, which encodes into the buffer.
+	encodeBytes := func(f func() error) ([]byte, error) {
+		buffer.Reset()
+		if err := f(); err != nil {
+			return nil, err
+		}
+		return bytes.Clone(buffer.Bytes()), nil
+	}
+	for iter := v.MapRange(); iter.Next(); {
+		var (
+			item entry
+			err  error
+		)
+		if item.key, err = encodeBytes(func() error {
+			if key := iter.Key(); typedKeys && !key.IsNil() {
+				if err := encoder.writeBytes(valueTagType, stringBytes(key.Elem().Type().String())); err != nil {
+					return err
+				}
+			}
+			return encoder.encode(iter.Key())
+		}); err != nil {
+			return err
+		}
+		if item.value, err = encodeBytes(func() error {
+			return encoder.encode(iter.Value())
+		}); err != nil {
+			return err
+		}
+		entries = append(entries, item)
+	}
+	// Different keys may be encoded the same, eg: NaNs, so the entries are also ordered by their values.
+	sort.Slice(entries, func(i, j int) bool {
+		if c := bytes.Compare(entries[i].key, entries[j].key); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(entries[i].value, entries[j].value) < 0
+	})
+	if err := e.writeUint(valueTagMap, uint64(len(entries))); err != nil {
+		return err
+	}
+	for _, item := range entries {
+		if _, err := e.writer.Write(item.key); err != nil {
+			return err
+		}
+		if _, err := e.writer.Write(item.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeStruct encodes the names and values of the fields of struct `v`, except the skipped fields.
+func (e *valueEncoder) encodeStruct(v reflect.Value) error {
+	var (
+		t      = v.Type()
+		fields = make([]int, 0, t.NumField())
+	)
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(valueTagName) != "-" {
+			fields = append(fields, i)
+		}
+	}
+	if err := e.writeUint(valueTagStruct, uint64(len(fields))); err != nil {
+		return err
+	}
+	for _, i := range fields {
+		if err := e.writeBytes(valueTagString, stringBytes(t.Field(i).Name)); err != nil {
+			return err
+		}
+		if err := e.encode(v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// visit calls `f` to encode reference `v`, or encodes a back reference if `v` is being visited.
+func (e *valueEncoder) visit(v reflect.Value, length int, f func() error) error {
+	key := valueVisit{ptr: v.Pointer(), length: length, typ: v.Type()}
+	if depth, ok := e.visiting[key]; ok {
+		return e.writeUint(valueTagCycle, uint64(depth))
+	}
+	e.visiting[key] = len(e.visiting)
+	defer delete(e.visiting, key)
+	return f()
+}
+
+// writeTag writes `tag` into the hash.
+func (e *valueEncoder) writeTag(tag byte) error {
+	e.scratch = append(e.scratch[:0], tag)
+	_, err := e.writer.Write(e.scratch)
+	return err
+}
+
+// writeUint writes `tag` and `n` into the hash.
+func (e *valueEncoder) writeUint(tag byte, n uint64) error {
+	e.scratch = binary.AppendUvarint(append(e.scratch[:0], tag), n)
+	_, err := e.writer.Write(e.scratch)
+	return err
+}
+
+// writeBytes writes `tag` and `data` prefixed with its length into the hash.
+func (e *valueEncoder) writeBytes(tag byte, data []byte) error {
+	if err := e.writeUint(tag, uint64(len(data))); err != nil {
+		return err
+	}
+	_, err := e.writer.Write(data)
+	return err
+}
+
+// valueFloatBits returns the bits of `f`, in which all zeros and all NaNs are canonical.
+func valueFloatBits(f float64) uint64 {
+	switch {
+	case f == 0:
+		return 0
+	case math.IsNaN(f):
+		return math.Float64bits(math.NaN())
+	default:
+		return math.Float64bits(f)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// pointerMarshaler implements encoding.BinaryMarshaler with pointer receiver.
+type pointerMarshaler struct {
+	id      int
+	ignored int
+}
+
+func (m *pointerMarshaler) MarshalBinary() ([]byte, error) {
+	return []byte{byte(m.id)}, nil
+}
+
+// valueMarshaler implements encoding.BinaryMarshaler with value receiver.
+type valueMarshaler struct {
+	id      int
+	ignored int
+}
+
+func (m valueMarshaler) MarshalBinary() ([]byte, error) {
+	return []byte{byte(m.id)}, nil
+}
+
+// mustValue returns the hash of `value`, and fails the test if it cannot be hashed.
+func mustValue(t *testing.T, value interface{}) uint64 {
+	t.Helper()
+	h, err := minhash.Value(value)
+	if err != nil {
+		t.Fatalf("Value(%#v) failed: %v", value, err)
+	}
+	return h
+}
+
+func Test_Value_Marshaler(t *testing.T) {
+	type pointerHolder struct {
+		M pointerMarshaler
+		m pointerMarshaler
+	}
+	type pointerRefHolder struct {
+		M *pointerMarshaler
+		m *pointerMarshaler
+	}
+	var (
+		p = pointerMarshaler{id: 1, ignored: 2}
+		v = valueMarshaler{id: 1, ignored: 3}
+	)
+	for _, c := range []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{"pointer receiver", p, &p},
+		{"pointer receiver ignores fields", p, pointerMarshaler{id: 1, ignored: 9}},
+		{"pointer receiver is value receiver", p, v},
+		{"value receiver", v, &v},
+		{"struct field", pointerHolder{M: p, m: p}, pointerRefHolder{M: &p, m: &p}},
+		{"map value", map[string]pointerMarshaler{"a": p}, map[string]*pointerMarshaler{"a": &p}},
+		{"interface", []interface{}{p}, []interface{}{&p}},
+		{"array", [1]pointerMarshaler{p}, []*pointerMarshaler{&p}},
+		{"time", time.Unix(1700000000, 0).UTC(), time.Unix(1700000000, 0).UTC()},
+	} {
+		if got, want := mustValue(t, c.value), mustValue(t, c.want); got != want {
+			t.Errorf("%s: Value = %#x, want %#x", c.name, got, want)
+		}
+	}
+}
+
+func Test_Value(t *testing.T) {
+	type tagged struct {
+		A int
+		B string `hash:"-"`
+		c []int
+	}
+	type node struct {
+		Next *node
+		V    int
+	}
+	cycle := &node{V: 1}
+	cycle.Next = cycle
+	for _, c := range []struct {
+		name  string
+		a, b  interface{}
+		equal bool
+	}{
+		{"int sizes", int8(7), uint64(7), false},
+		{"int widths", int8(7), int64(7), true},
+		{"float widths", float32(0.5), 0.5, true},
+		{"map order", map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, true},
+		{"map values", map[string]int{"a": 1}, map[string]int{"a": 2}, false},
+		{"skipped field", tagged{A: 1, B: "x"}, tagged{A: 1, B: "y"}, true},
+		{"unexported field", tagged{c: []int{1}}, tagged{c: []int{2}}, false},
+		{"pointer", &tagged{A: 1}, tagged{A: 1}, true},
+		{"nil slice", []int(nil), []int{}, false},
+		{"string bytes", "ab", []byte("ab"), false},
+		{"list boundary", []string{"ab", "c"}, []string{"a", "bc"}, false},
+		{"cycle", cycle, &node{V: 1, Next: &node{V: 1}}, false},
+	} {
+		if equal := mustValue(t, c.a) == mustValue(t, c.b); equal != c.equal {
+			t.Errorf("%s: equal = %v, want %v", c.name, equal, c.equal)
+		}
+	}
+	if mustValue(t, cycle) != mustValue(t, cycle) {
+		t.Fatal("Value of cycle is not deterministic")
+	}
+	for _, invalid := range []interface{}{func() {}, make(chan int), struct{ F func() }{}} {
+		if _, err := minhash.Value(invalid); err == nil {
+			t.Errorf("Value(%T) succeeds", invalid)
+		}
+	}
+	fnv, _ := minhash.Lookup("fnv1a32")
+	if h, err := minhash.Value(1, fnv); err != nil || h>>32 != 0 || h == mustValue(t, 1) {
+		t.Fatalf("Value with 32 bits hasher = %#x, %v", h, err)
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	xxh3SecretSize      = 192 // Size of the default secret.
+	xxh3SecretSizeMin   = 136 // Minimum secret size defined by the specification.
+	xxh3StripeLen       = 64  // Number of input bytes consumed by one accumulation.
+	xxh3ConsumeRate     = 8   // Number of secret bytes advanced per stripe.
+	xxh3LastAccStart    = 7   // Secret offset for the last stripe.
+	xxh3MergeAccsStart  = 11  // Secret offset for merging accumulators.
+	xxh3MidSizeStart    = 3   // Secret offset for inputs of 129-240 bytes.
+	xxh3MidSizeLast     = 17  // Secret offset for the last bytes of inputs of 129-240 bytes.
+	xxh3MidSizeMax      = 240 // Maximum input size using the mid-size algorithm.
+	xxh3BufferSize      = 256 // Size of the buffer of pending bytes in streaming, which is a multiple of stripes.
+	xxh3AvalancheMul    = 0x165667919e3779f9
+	xxh3RrmxmxMul       = 0x9fb21c651e98df25
+	xxh3Prime32x2Minus1 = xxhPrime32x2 - 1
+)
+
+// xxh3Secret is the default secret of XXH3.
+var xxh3Secret = [xxh3SecretSize]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+// xxh3InitAcc is the initial accumulators of inputs longer than 240 bytes.
+var xxh3InitAcc = [8]uint64{
+	xxhPrime32x3, xxhPrime64x1, xxhPrime64x2, xxhPrime64x3,
+	xxhPrime64x4, xxhPrime32x2, xxhPrime64x5, xxhPrime32x1,
+}
+
+// XXH3 implements the XXH3 hash algorithm for 64 bits with seed 0.
+func XXH3(str []byte) uint64 {
+	return XXH3Seed(0, str)
+}
+
+// XXH3String is the same as XXH3 for string `str`, which hashes `str` without copying it.
+func XXH3String(str string) uint64 {
+	return XXH3(stringBytes(str))
+}
+
+// XXH3Seed implements the XXH3 hash algorithm for 64 bits with given `seed`.
+func XXH3Seed(seed uint64, str []byte) uint64 {
+	var (
+		secret = xxh3Secret[:]
+		length = uint64(len(str))
+	)
+	switch {
+	case len(str) <= 16:
+		return xxh3Len0To16(str, secret, seed)
+
+	case len(str) <= 128:
+		acc := length * xxhPrime64x1
+		if len(str) > 32 {
+			if len(str) > 64 {
+				if len(str) > 96 {
+					acc += xxh3Mix16(str[48:], secret[96:], seed)
+					acc += xxh3Mix16(str[len(str)-64:], secret[112:], seed)
+				}
+				acc += xxh3Mix16(str[32:], secret[64:], seed)
+				acc += xxh3Mix16(str[len(str)-48:], secret[80:], seed)
+			}
+			acc += xxh3Mix16(str[16:], secret[32:], seed)
+			acc += xxh3Mix16(str[len(str)-32:], secret[48:], seed)
+		}
+		acc += xxh3Mix16(str, secret, seed)
+		acc += xxh3Mix16(str[len(str)-16:], secret[16:], seed)
+		return xxh3Avalanche(acc)
+
+	case len(str) <= xxh3MidSizeMax:
+		acc := length * xxhPrime64x1
+		rounds := len(str) / 16
+		for i := 0; i < 8; i++ {
+			acc += xxh3Mix16(str[16*i:], secret[16*i:], seed)
+		}
+		acc = xxh3Avalanche(acc)
+		for i := 8; i < rounds; i++ {
+			acc += xxh3Mix16(str[16*i:], secret[16*(i-8)+xxh3MidSizeStart:], seed)
+		}
+		acc += xxh3Mix16(str[len(str)-16:], secret[xxh3SecretSizeMin-xxh3MidSizeLast:], seed)
+		return xxh3Avalanche(acc)
+
+	default:
+		if seed != 0 {
+			secret = xxh3CustomSecret(seed)
+		}
+		acc := xxh3HashLong(str, secret)
+		return xxh3MergeAccs(&acc, secret[xxh3MergeAccsStart:], length*xxhPrime64x1)
+	}
+}
+
+// XXH3SeedString is the same as XXH3Seed for string `str`, which hashes `str` without copying it.
+func XXH3SeedString(seed uint64, str string) uint64 {
+	return XXH3Seed(seed, stringBytes(str))
+}
+
+// XXH128 implements the XXH3 hash algorithm for 128 bits with seed 0.
+func XXH128(str []byte) Uint128 {
+	return XXH128Seed(0, str)
+}
+
+// XXH128String is the same as XXH128 for string `str`, which hashes `str` without copying it.
+func XXH128String(str string) Uint128 {
+	return XXH128(stringBytes(str))
+}
+
+// XXH128Seed implements the XXH3 hash algorithm for 128 bits with given `seed`.
+func XXH128Seed(seed uint64, str []byte) Uint128 {
+	var (
+		secret = xxh3Secret[:]
+		length = uint64(len(str))
+	)
+	switch {
+	case len(str) <= 16:
+		return xxh128Len0To16(str, secret, seed)
+
+	case len(str) <= 128:
+		acc := Uint128{Lo: length * xxhPrime64x1}
+		if len(str) > 32 {
+			if len(str) > 64 {
+				if len(str) > 96 {
+					acc = xxh128Mix32(acc, str[48:], str[len(str)-64:], secret[96:], seed)
+				}
+				acc = xxh128Mix32(acc, str[32:], str[len(str)-48:], secret[64:], seed)
+			}
+			acc = xxh128Mix32(acc, str[16:], str[len(str)-32:], secret[32:], seed)
+		}
+		acc = xxh128Mix32(acc, str, str[len(str)-16:], secret, seed)
+		return xxh128Finalize(acc, length, seed)
+
+	case len(str) <= xxh3MidSizeMax:
+		acc := Uint128{Lo: length * xxhPrime64x1}
+		rounds := len(str) / 32
+		for i := 0; i < 4; i++ {
+			acc = xxh128Mix32(acc, str[32*i:], str[32*i+16:], secret[32*i:], seed)
+		}
+		acc.Lo = xxh3Avalanche(acc.Lo)
+		acc.Hi = xxh3Avalanche(acc.Hi)
+		for i := 4; i < rounds; i++ {
+			acc = xxh128Mix32(acc, str[32*i:], str[32*i+16:], secret[32*(i-4)+xxh3MidSizeStart:], seed)
+		}
+		acc = xxh128Mix32(
+			acc, str[len(str)-16:], str[len(str)-32:],
+			secret[xxh3SecretSizeMin-xxh3MidSizeLast-16:], -seed,
+		)
+		return xxh128Finalize(acc, length, seed)
+
+	default:
+		if seed != 0 {
+			secret = xxh3CustomSecret(seed)
+		}
+		acc := xxh3HashLong(str, secret)
+		return Uint128{
+			Lo: xxh3MergeAccs(&acc, secret[xxh3MergeAccsStart:], length*xxhPrime64x1),
+			Hi: xxh3MergeAccs(&acc, secret[len(secret)-xxh3StripeLen-xxh3MergeAccsStart:], ^(length * xxhPrime64x2)),
+		}
+	}
+}
+
+// XXH128SeedString is the same as XXH128Seed for string `str`, which hashes `str` without copying it.
+func XXH128SeedString(seed uint64, str string) Uint128 {
+	return XXH128Seed(seed, stringBytes(str))
+}
+
+// xxh3Len0To16 hashes inputs of at most 16 bytes for 64 bits.
+func xxh3Len0To16(str, secret []byte, seed uint64) uint64 {
+	length := uint64(len(str))
+	switch {
+	case len(str) > 8:
+		bitFlip1 := (xxh3Read64(secret, 24) ^ xxh3Read64(secret, 32)) + seed
+		bitFlip2 := (xxh3Read64(secret, 40) ^ xxh3Read64(secret, 48)) - seed
+		inputLo := xxh3Read64(str, 0) ^ bitFlip1
+		inputHi := xxh3Read64(str, len(str)-8) ^ bitFlip2
+		acc := length + bits.ReverseBytes64(inputLo) + inputHi + xxh3MulFold64(inputLo, inputHi)
+		return xxh3Avalanche(acc)
+
+	case len(str) >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		input1 := uint64(xxh3Read32(str, 0))
+		input2 := uint64(xxh3Read32(str, len(str)-4))
+		bitFlip := (xxh3Read64(secret, 8) ^ xxh3Read64(secret, 16)) - seed
+		return xxh3Rrmxmx((input2+input1<<32)^bitFlip, length)
+
+	case len(str) > 0:
+		combined := uint32(str[0])<<16 | uint32(str[len(str)>>1])<<24 | uint32(str[len(str)-1]) | uint32(len(str))<<8
+		bitFlip := uint64(xxh3Read32(secret, 0)^xxh3Read32(secret, 4)) + seed
+		return xxh64Avalanche(uint64(combined) ^ bitFlip)
+
+	default:
+		return xxh64Avalanche(seed ^ (xxh3Read64(secret, 56) ^ xxh3Read64(secret, 64)))
+	}
+}
+
+// xxh128Len0To16 hashes inputs of at most 16 bytes for 128 bits.
+func xxh128Len0To16(str, secret []byte, seed uint64) Uint128 {
+	length := uint64(len(str))
+	switch {
+	case len(str) > 8:
+		bitFlipL := (xxh3Read64(secret, 32) ^ xxh3Read64(secret, 40)) - seed
+		bitFlipH := (xxh3Read64(secret, 48) ^ xxh3Read64(secret, 56)) + seed
+		inputLo := xxh3Read64(str, 0)
+		inputHi := xxh3Read64(str, len(str)-8)
+		mHi, mLo := bits.Mul64(inputLo^inputHi^bitFlipL, xxhPrime64x1)
+		mLo += (length - 1) << 54
+		inputHi ^= bitFlipH
+		mHi += inputHi + uint64(uint32(inputHi))*xxh3Prime32x2Minus1
+		mLo ^= bits.ReverseBytes64(mHi)
+		hHi, hLo := bits.Mul64(mLo, xxhPrime64x2)
+		hHi += mHi * xxhPrime64x2
+		return Uint128{Hi: xxh3Avalanche(hHi), Lo: xxh3Avalanche(hLo)}
+
+	case len(str) >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		inputLo := uint64(xxh3Read32(str, 0))
+		inputHi := uint64(xxh3Read32(str, len(str)-4))
+		bitFlip := (xxh3Read64(secret, 16) ^ xxh3Read64(secret, 24)) + seed
+		keyed := (inputLo + inputHi<<32) ^ bitFlip
+		mHi, mLo := bits.Mul64(keyed, xxhPrime64x1+length<<2)
+		mHi += mLo << 1
+		mLo ^= mHi >> 3
+		mLo ^= mLo >> 35
+		mLo *= xxh3RrmxmxMul
+		mLo ^= mLo >> 28
+		return Uint128{Hi: xxh3Avalanche(mHi), Lo: mLo}
+
+	case len(str) > 0:
+		combinedL := uint32(str[0])<<16 | uint32(str[len(str)>>1])<<24 | uint32(str[len(str)-1]) | uint32(len(str))<<8
+		combinedH := bits.RotateLeft32(bits.ReverseBytes32(combinedL), 13)
+		bitFlipL := uint64(xxh3Read32(secret, 0)^xxh3Read32(secret, 4)) + seed
+		bitFlipH := uint64(xxh3Read32(secret, 8)^xxh3Read32(secret, 12)) - seed
+		return Uint128{
+			Hi: xxh64Avalanche(uint64(combinedH) ^ bitFlipH),
+			Lo: xxh64Avalanche(uint64(combinedL) ^ bitFlipL),
+		}
+
+	default:
+		bitFlipL := xxh3Read64(secret, 64) ^ xxh3Read64(secret, 72)
+		bitFlipH := xxh3Read64(secret, 80) ^ xxh3Read64(secret, 88)
+		return Uint128{
+			Hi: xxh64Avalanche(seed ^ bitFlipH),
+			Lo: xxh64Avalanche(seed ^ bitFlipL),
+		}
+	}
+}
+
+// xxh3HashLong accumulates inputs longer than 240 bytes with `secret`.
+func xxh3HashLong(str, secret []byte) [8]uint64 {
+	var (
+		acc             = xxh3InitAcc
+		stripesPerBlock = (len(secret) - xxh3StripeLen) / xxh3ConsumeRate
+		blockLen        = xxh3StripeLen * stripesPerBlock
+		blocks          = (len(str) - 1) / blockLen
+	)
+	for n := 0; n < blocks; n++ {
+		xxh3Accumulate(&acc, str[n*blockLen:], secret, stripesPerBlock)
+		xxh3ScrambleAcc(&acc, secret[len(secret)-xxh3StripeLen:])
+	}
+	stripes := ((len(str) - 1) - blockLen*blocks) / xxh3StripeLen
+	xxh3Accumulate(&acc, str[blocks*blockLen:], secret, stripes)
+	xxh3Accumulate512(&acc, str[len(str)-xxh3StripeLen:], secret[len(secret)-xxh3StripeLen-xxh3LastAccStart:])
+	return acc
+}
+
+// xxh3ConsumeStripes accumulates `stripes` stripes of `str` into `acc` continuing the current block,
+// in which `blockStripes` stripes are already accumulated, and scrambles `acc` at the end of the block.
+// The number of stripes must not exceed the stripes of a block.
+func xxh3ConsumeStripes(acc *[8]uint64, blockStripes *int, str, secret []byte, stripes int) {
+	stripesPerBlock := (len(secret) - xxh3StripeLen) / xxh3ConsumeRate
+	if toEnd := stripesPerBlock - *blockStripes; toEnd <= stripes {
+		xxh3Accumulate(acc, str, secret[*blockStripes*xxh3ConsumeRate:], toEnd)
+		xxh3ScrambleAcc(acc, secret[len(secret)-xxh3StripeLen:])
+		xxh3Accumulate(acc, str[toEnd*xxh3StripeLen:], secret, stripes-toEnd)
+		*blockStripes = stripes - toEnd
+		return
+	}
+	xxh3Accumulate(acc, str, secret[*blockStripes*xxh3ConsumeRate:], stripes)
+	*blockStripes += stripes
+}
+
+// xxh3Accumulate accumulates `stripes` stripes of `str` into `acc`.
+func xxh3Accumulate(acc *[8]uint64, str, secret []byte, stripes int) {
+	for n := 0; n < stripes; n++ {
+		xxh3Accumulate512(acc, str[n*xxh3StripeLen:], secret[n*xxh3ConsumeRate:])
+	}
+}
+
+// xxh3Accumulate512 accumulates one 64 bytes stripe of `str` into `acc`.
+func xxh3Accumulate512(acc *[8]uint64, str, secret []byte) {
+	for i := 0; i < 8; i++ {
+		dataVal := xxh3Read64(str, 8*i)
+		dataKey := dataVal ^ xxh3Read64(secret, 8*i)
+		acc[i^1] += dataVal
+		acc[i] += uint64(uint32(dataKey)) * (dataKey >> 32)
+	}
+}
+
+// xxh3ScrambleAcc scrambles `acc` at the end of each block.
+func xxh3ScrambleAcc(acc *[8]uint64, secret []byte) {
+	for i := 0; i < 8; i++ {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= xxh3Read64(secret, 8*i)
+		a *= xxhPrime32x1
+		acc[i] = a
+	}
+}
+
+// xxh3MergeAccs merges the accumulators `acc` into a single 64 bits value.
+func xxh3MergeAccs(acc *[8]uint64, secret []byte, start uint64) uint64 {
+	result := start
+	for i := 0; i < 4; i++ {
+		result += xxh3MulFold64(acc[2*i]^xxh3Read64(secret, 16*i), acc[2*i+1]^xxh3Read64(secret, 16*i+8))
+	}
+	return xxh3Avalanche(result)
+}
+
+// xxh3CustomSecret derives a secret from the default secret and `seed` for long inputs.
+func xxh3CustomSecret(seed uint64) []byte {
+	secret := make([]byte, xxh3SecretSize)
+	for i := 0; i < xxh3SecretSize/16; i++ {
+		binary.LittleEndian.PutUint64(secret[16*i:], xxh3Read64(xxh3Secret[:], 16*i)+seed)
+		binary.LittleEndian.PutUint64(secret[16*i+8:], xxh3Read64(xxh3Secret[:], 16*i+8)-seed)
+	}
+	return secret
+}
+
+// xxh3Mix16 mixes 16 bytes of `str` with 16 bytes of `secret` and `seed`.
+func xxh3Mix16(str, secret []byte, seed uint64) uint64 {
+	return xxh3MulFold64(
+		xxh3Read64(str, 0)^(xxh3Read64(secret, 0)+seed),
+		xxh3Read64(str, 8)^(xxh3Read64(secret, 8)-seed),
+	)
+}
+
+// xxh128Mix32 mixes two 16 bytes inputs `str1` and `str2` into `acc`.
+func xxh128Mix32(acc Uint128, str1, str2, secret []byte, seed uint64) Uint128 {
+	acc.Lo += xxh3Mix16(str1, secret, seed)
+	acc.Lo ^= xxh3Read64(str2, 0) + xxh3Read64(str2, 8)
+	acc.Hi += xxh3Mix16(str2, secret[16:], seed)
+	acc.Hi ^= xxh3Read64(str1, 0) + xxh3Read64(str1, 8)
+	return acc
+}
+
+// xxh128Finalize produces the 128 bits result for inputs of 17-240 bytes.
+func xxh128Finalize(acc Uint128, length, seed uint64) Uint128 {
+	lo := acc.Lo + acc.Hi
+	hi := acc.Lo*xxhPrime64x1 + acc.Hi*xxhPrime64x4 + (length-seed)*xxhPrime64x2
+	return Uint128{Hi: -xxh3Avalanche(hi), Lo: xxh3Avalanche(lo)}
+}
+
+// xxh3Avalanche is the final mix of XXH3.
+func xxh3Avalanche(hash uint64) uint64 {
+	hash ^= hash >> 37
+	hash *= xxh3AvalancheMul
+	hash ^= hash >> 32
+	return hash
+}
+
+// xxh3Rrmxmx is the final mix of XXH3 for inputs of 4-8 bytes.
+func xxh3Rrmxmx(hash, length uint64) uint64 {
+	hash ^= bits.RotateLeft64(hash, 49) ^ bits.RotateLeft64(hash, 24)
+	hash *= xxh3RrmxmxMul
+	hash ^= (hash >> 35) + length
+	hash *= xxh3RrmxmxMul
+	hash ^= hash >> 28
+	return hash
+}
+
+// xxh3MulFold64 multiplies `x` and `y` into 128 bits and folds it with xor into 64 bits.
+func xxh3MulFold64(x, y uint64) uint64 {
+	hi, lo := bits.Mul64(x, y)
+	return hi ^ lo
+}
+
+func xxh3Read32(b []byte, offset int) uint32 {
+	return binary.LittleEndian.Uint32(b[offset:])
+}
+
+func xxh3Read64(b []byte, offset int) uint64 {
+	return binary.LittleEndian.Uint64(b[offset:])
+}
+
+// xxh3State is the streaming state of XXH3 shared by the 64 and 128 bits variants.
+// Full stripes are accumulated as soon as more data follows them, so that only
+// the last xxh3BufferSize bytes at most are kept in memory.
+type xxh3State struct {
+	seed    uint64
+	secret  []byte // Default secret, or the custom secret derived from seed.
+	acc     [8]uint64
+	stripes int // Number of stripes accumulated in the current block.
+	// Pending bytes. Its last stripe keeps the last consumed stripe after the data are consumed,
+	// which the last stripe of the digest starts in if less than a stripe is pending.
+	buf    [xxh3BufferSize]byte
+	n      int    // Number of pending bytes in buf.
+	length uint64 // Total number of written bytes.
+}
+
+// newXXH3State creates and returns the streaming state of XXH3 for `seed`.
+func newXXH3State(seed uint64) xxh3State {
+	s := xxh3State{seed: seed, secret: xxh3Secret[:]}
+	if seed != 0 {
+		s.secret = xxh3CustomSecret(seed)
+	}
+	s.Reset()
+	return s
+}
+
+func (s *xxh3State) Reset() {
+	s.acc = xxh3InitAcc
+	s.stripes = 0
+	s.n = 0
+	s.length = 0
+}
+
+func (s *xxh3State) Write(data []byte) (int, error) {
+	length := len(data)
+	s.length += uint64(length)
+	// The data are kept pending until they exceed the buffer, as the last stripe must not be
+	// accumulated before it is known to be the last one.
+	if s.n+len(data) <= len(s.buf) {
+		s.n += copy(s.buf[s.n:], data)
+		return length, nil
+	}
+	if s.n > 0 {
+		c := copy(s.buf[s.n:], data)
+		data = data[c:]
+		s.consume(s.buf[:], xxh3BufferSize/xxh3StripeLen)
+		s.n = 0
+	}
+	if len(data) > len(s.buf) {
+		var consumed []byte
+		for len(data) > len(s.buf) {
+			s.consume(data, xxh3BufferSize/xxh3StripeLen)
+			consumed, data = data[:xxh3BufferSize], data[xxh3BufferSize:]
+		}
+		copy(s.buf[len(s.buf)-xxh3StripeLen:], consumed[len(consumed)-xxh3StripeLen:])
+	}
+	s.n = copy(s.buf[:], data)
+	return length, nil
+}
+
+func (s *xxh3State) BlockSize() int { return xxh3StripeLen }
+
+// consume accumulates `stripes` stripes of `data` into the state,
+// scrambling the accumulators at the end of each block.
+func (s *xxh3State) consume(data []byte, stripes int) {
+	xxh3ConsumeStripes(&s.acc, &s.stripes, data, s.secret, stripes)
+}
+
+// digest returns the accumulators of the written data with the last stripe accumulated,
+// which are only valid if more than xxh3MidSizeMax bytes are written.
+func (s *xxh3State) digest() [8]uint64 {
+	var (
+		acc     = s.acc
+		stripes = s.stripes
+		last    [xxh3StripeLen]byte
+	)
+	if s.n >= xxh3StripeLen {
+		xxh3ConsumeStripes(&acc, &stripes, s.buf[:], s.secret, (s.n-1)/xxh3StripeLen)
+		copy(last[:], s.buf[s.n-xxh3StripeLen:s.n])
+	} else {
+		// The last stripe starts in the previously consumed data kept at the end of the buffer.
+		c := copy(last[:], s.buf[len(s.buf)-(xxh3StripeLen-s.n):])
+		copy(last[c:], s.buf[:s.n])
+	}
+	xxh3Accumulate512(&acc, last[:], s.secret[len(s.secret)-xxh3StripeLen-xxh3LastAccStart:])
+	return acc
+}
+
+type xxh3x64 struct {
+	xxh3State
+}
+
+type xxh3x128 struct {
+	xxh3State
+}
+
+// NewXXH3 returns a new hash.Hash64 computing the XXH3 hash algorithm for 64 bits with seed 0.
+// Its Sum64 result is identical to XXH3 for the same written data.
+func NewXXH3() hash.Hash64 {
+	return NewXXH3Seed(0)
+}
+
+// NewXXH3Seed returns a new hash.Hash64 computing the XXH3 hash algorithm for 64 bits with given `seed`.
+// Its Sum64 result is identical to XXH3Seed for the same written data.
+func NewXXH3Seed(seed uint64) hash.Hash64 {
+	return &xxh3x64{newXXH3State(seed)}
+}
+
+func (s *xxh3x64) Sum64() uint64 {
+	if s.length <= xxh3MidSizeMax {
+		return XXH3Seed(s.seed, s.buf[:s.n])
+	}
+	acc := s.digest()
+	return xxh3MergeAccs(&acc, s.secret[xxh3MergeAccsStart:], s.length*xxhPrime64x1)
+}
+
+func (s *xxh3x64) Size() int { return 8 }
+
+func (s *xxh3x64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, s.Sum64()) }
+
+// NewXXH128 returns a new hash.Hash computing the XXH3 hash algorithm for 128 bits with seed 0.
+// Its Sum result is the big-endian bytes of XXH128 for the same written data.
+func NewXXH128() hash.Hash {
+	return NewXXH128Seed(0)
+}
+
+// NewXXH128Seed returns a new hash.Hash computing the XXH3 hash algorithm for 128 bits with given `seed`.
+// Its Sum result is the big-endian bytes of XXH128Seed for the same written data.
+func NewXXH128Seed(seed uint64) hash.Hash {
+	return &xxh3x128{newXXH3State(seed)}
+}
+
+// Sum128 returns the digest of the written data.
+func (s *xxh3x128) Sum128() Uint128 {
+	if s.length <= xxh3MidSizeMax {
+		return XXH128Seed(s.seed, s.buf[:s.n])
+	}
+	acc := s.digest()
+	return Uint128{
+		Lo: xxh3MergeAccs(&acc, s.secret[xxh3MergeAccsStart:], s.length*xxhPrime64x1),
+		Hi: xxh3MergeAccs(&acc, s.secret[len(s.secret)-xxh3StripeLen-xxh3MergeAccsStart:], ^(s.length * xxhPrime64x2)),
+	}
+}
+
+func (s *xxh3x128) Size() int { return 16 }
+
+func (s *xxh3x128) Sum(in []byte) []byte {
+	b := s.Sum128().Bytes()
+	return append(in, b[:]...)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+// xxh3Input returns the input of `n` bytes for the XXH3 tests.
+func xxh3Input(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte((i + 1) % 251)
+	}
+	return data
+}
+
+// xxh3Vectors are the digests of xxh3Input by the reference implementation of XXH3,
+// in which the seeded digests use the unseeded digest of XXH3 as the seed.
+var xxh3Vectors = []struct {
+	length     int
+	xxh3       uint64
+	xxh3Seed   uint64
+	xxh128     minhash.Uint128
+	xxh128Seed minhash.Uint128
+}{
+	{0, 0x2d06800538d394c2, 0x412f1275e10017f3, minhash.Uint128{Hi: 0x99aa06d3014798d8, Lo: 0x6001c324468d497f}, minhash.Uint128{Hi: 0x7a3edd697cf3f953, Lo: 0x5310e30cce3262d6}},
+	{1, 0xe12ef9d2eb86ceeb, 0x4262213496108755, minhash.Uint128{Hi: 0x51025a4491835505, Lo: 0xe12ef9d2eb86ceeb}, minhash.Uint128{Hi: 0xdbd12cf835d1d791, Lo: 0x4262213496108755}},
+	{2, 0x08130b77ddef5807, 0x42ff1fffac777e8f, minhash.Uint128{Hi: 0xd2f0f9428898845f, Lo: 0x08130b77ddef5807}, minhash.Uint128{Hi: 0xb14576764ab861b3, Lo: 0x42ff1fffac777e8f}},
+	{3, 0xebce9b7632ae733b, 0xfb7293fb3dbdac25, minhash.Uint128{Hi: 0xac77eb88cbc4b8d4, Lo: 0xebce9b7632ae733b}, minhash.Uint128{Hi: 0xb0d9e32e570e9f22, Lo: 0xfb7293fb3dbdac25}},
+	{4, 0x988b7b9033ac4622, 0x48f347023f9c957e, minhash.Uint128{Hi: 0x49a04899597a3567, Lo: 0x537653a0d9955b86}, minhash.Uint128{Hi: 0x0e2783501d2791a3, Lo: 0x5754a8527fe29706}},
+	{6, 0xc7de39ae11689bef, 0x19c3d853b927bee7, minhash.Uint128{Hi: 0x866737830f560dbf, Lo: 0x3e1f439d2d785f44}, minhash.Uint128{Hi: 0xab1d2845b01b6971, Lo: 0xeeb2344ca8b60916}},
+	{8, 0x16f217ea16232297, 0xdccb3547423b9f24, minhash.Uint128{Hi: 0x2ab463fddb09a0b8, Lo: 0x3e8675c57268fb02}, minhash.Uint128{Hi: 0xb47216ae460a2dd1, Lo: 0x012f8c64e0dd7eeb}},
+	{9, 0x17d143e7f447850a, 0xe1b6f82d24211d46, minhash.Uint128{Hi: 0xe338e616502be361, Lo: 0x3c4087b7dea54fc0}, minhash.Uint128{Hi: 0xb71f28f317071c7e, Lo: 0x1b6795721e0ff4ad}},
+	{12, 0x46878d25c61dfc0f, 0x23b7337a314760fa, minhash.Uint128{Hi: 0xbe3749deafac90b6, Lo: 0x5b070b3f0db539ff}, minhash.Uint128{Hi: 0x306bbb28eb64e6ef, Lo: 0x60fb1c5b9cd877ec}},
+	{16, 0xeb5aeb9a32450f6a, 0xe9a6d2d94e8991c1, minhash.Uint128{Hi: 0x6d84a882f6411b41, Lo: 0xeada823104bd7174}, minhash.Uint128{Hi: 0xc56b786a273a82dc, Lo: 0xe0db3902ce4ddc50}},
+	{17, 0x6d458e1fff494078, 0x54019c5cc05b1fcc, minhash.Uint128{Hi: 0x9ac14e2c3fe59a83, Lo: 0xacda8373034d6aaf}, minhash.Uint128{Hi: 0xbc4f5e8e54acc774, Lo: 0xb24b36021f73ccc1}},
+	{64, 0xc82013245d8f2587, 0x4d758cc61029b22b, minhash.Uint128{Hi: 0x21bfcfd7d148a3df, Lo: 0x639fbd9cf9bdfb51}, minhash.Uint128{Hi: 0x6eeafc6467246b3f, Lo: 0xefe802db83f1791d}},
+	{100, 0xd53e74fac84fa8fb, 0x6f67e6c565344887, minhash.Uint128{Hi: 0x908495a789d76a98, Lo: 0x0dc4a6a00105b3fa}, minhash.Uint128{Hi: 0xf28caab10dafb328, Lo: 0x2744a3e67949195b}},
+	{128, 0xce22cae9106851df, 0x89f0b391c1134b63, minhash.Uint128{Hi: 0x763fdbd9fc602233, Lo: 0x7ac9e58028da0fc7}, minhash.Uint128{Hi: 0x29d210dff1f84a0d, Lo: 0x08bef7bf144a2bf8}},
+	{129, 0x7d4fc663f5958d40, 0x5d32d64c42cb3d9e, minhash.Uint128{Hi: 0x4c8ce7bd2a6024b3, Lo: 0x88cb4305c9a32490}, minhash.Uint128{Hi: 0x7ed34d8231f1ee2f, Lo: 0xd72dec8fb55284ff}},
+	{192, 0x3edbd090b152e275, 0x0b8c7388d8a1daa0, minhash.Uint128{Hi: 0x4f7d724c22482f73, Lo: 0x3afbb6143da36c4b}, minhash.Uint128{Hi: 0x8fd5a5e3b425f9d9, Lo: 0x30fd8e6a13341a41}},
+	{240, 0xa5a910b2d7e065b0, 0x80e2216803241284, minhash.Uint128{Hi: 0x2007e6f83d506ea3, Lo: 0xdde80e1ba2971e09}, minhash.Uint128{Hi: 0xea6d1795f8170090, Lo: 0x00022db7cc107adc}},
+	{241, 0xb6515f490cdd4ce5, 0x5d5615c096fc7d65, minhash.Uint128{Hi: 0x956bc01534a3752b, Lo: 0xb6515f490cdd4ce5}, minhash.Uint128{Hi: 0xfa32833bc92577f9, Lo: 0x5d5615c096fc7d65}},
+	{500, 0x5122fee3b84f4d1b, 0xcb3577181b66ede4, minhash.Uint128{Hi: 0x6acc268e9bc87c07, Lo: 0x5122fee3b84f4d1b}, minhash.Uint128{Hi: 0xb1419f2609a41348, Lo: 0xcb3577181b66ede4}},
+	{1023, 0xe53c987a6f064659, 0x4108b521511e2d61, minhash.Uint128{Hi: 0x7a9b2b36577fb216, Lo: 0xe53c987a6f064659}, minhash.Uint128{Hi: 0x830334aeb9657804, Lo: 0x4108b521511e2d61}},
+}
+
+func Test_XXH3_Vectors(t *testing.T) {
+	for _, v := range xxh3Vectors {
+		var (
+			data = xxh3Input(v.length)
+			seed = v.xxh3
+		)
+		if got := minhash.XXH3(data); got != v.xxh3 {
+			t.Errorf("XXH3 of %d bytes = %#x, want %#x", v.length, got, v.xxh3)
+		}
+		if got := minhash.XXH3Seed(seed, data); got != v.xxh3Seed {
+			t.Errorf("XXH3Seed of %d bytes = %#x, want %#x", v.length, got, v.xxh3Seed)
+		}
+		if got := minhash.XXH128(data); got != v.xxh128 {
+			t.Errorf("XXH128 of %d bytes = %#x, want %#x", v.length, got, v.xxh128)
+		}
+		if got := minhash.XXH128Seed(seed, data); got != v.xxh128Seed {
+			t.Errorf("XXH128Seed of %d bytes = %#x, want %#x", v.length, got, v.xxh128Seed)
+		}
+		h := minhash.NewXXH3Seed(seed)
+		h.Write(data)
+		if got := h.Sum64(); got != v.xxh3Seed {
+			t.Errorf("NewXXH3Seed of %d bytes = %#x, want %#x", v.length, got, v.xxh3Seed)
+		}
+	}
+}
+
+func Test_XXH3_Streaming(t *testing.T) {
+	// The lengths cover the buffered inputs of at most 240 bytes, the boundaries of the buffer (256 bytes)
+	// and of the blocks (1024 bytes), and inputs of multiple blocks.
+	var (
+		data   = xxh3Input(4500)
+		chunks = []int{1, 7, 63, 64, 65, 255, 256, 257, 1024, 1025, len(data)}
+	)
+	for n := 0; n <= len(data); n++ {
+		if n > 2100 && n%97 != 0 {
+			continue
+		}
+		for _, seed := range []uint64{0, 7} {
+			var (
+				want    = minhash.XXH3Seed(seed, data[:n])
+				want128 = minhash.XXH128Seed(seed, data[:n]).Bytes()
+				h       = minhash.NewXXH3Seed(seed)
+				h128    = minhash.NewXXH128Seed(seed)
+			)
+			for _, chunk := range chunks {
+				// The hashes are reused after Reset.
+				h.Reset()
+				h128.Reset()
+				for i := 0; i < n; i += chunk {
+					h.Write(data[i:min(n, i+chunk)])
+					h128.Write(data[i:min(n, i+chunk)])
+				}
+				if got := h.Sum64(); got != want {
+					t.Fatalf("length %d seed %d chunk %d: Sum64 = %#x, want %#x", n, seed, chunk, got, want)
+				}
+				if got := h128.Sum(nil); !bytes.Equal(got, want128[:]) {
+					t.Fatalf("length %d seed %d chunk %d: Sum = %x, want %x", n, seed, chunk, got, want128)
+				}
+			}
+		}
+	}
+}
+
+func Test_XXH3_StreamingMemory(t *testing.T) {
+	var (
+		data = xxh3Input(1 << 16)
+		h    = minhash.NewXXH3()
+	)
+	// The written data are accumulated instead of being buffered.
+	if n := testing.AllocsPerRun(10, func() { h.Write(data) }); n != 0 {
+		t.Fatalf("Write allocates %v times", n)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	xxhPrime32x1 uint64 = 2654435761
+	xxhPrime32x2 uint64 = 2246822519
+	xxhPrime32x3 uint64 = 3266489917
+	xxhPrime64x1 uint64 = 11400714785074694791
+	xxhPrime64x2 uint64 = 14029467366897019727
+	xxhPrime64x3 uint64 = 1609587929392839161
+	xxhPrime64x4 uint64 = 9650029242287828579
+	xxhPrime64x5 uint64 = 2870177450012600261
+)
+
+// XXH64 implements the xxHash64 hash algorithm for 64 bits with seed 0.
+func XXH64(str []byte) uint64 {
+	return XXH64Seed(0, str)
+}
+
+// XXH64String is the same as XXH64 for string `str`, which hashes `str` without copying it.
+func XXH64String(str string) uint64 {
+	return XXH64(stringBytes(str))
+}
+
+// XXH64Seed implements the xxHash64 hash algorithm for 64 bits with given `seed`.
+func XXH64Seed(seed uint64, str []byte) uint64 {
+	var (
+		hash   uint64
+		length = uint64(len(str))
+	)
+	if len(str) >= 32 {
+		v := xxh64Lanes(seed)
+		str = xxh64Stripes(&v, str)
+		hash = xxh64MergeLanes(&v)
+	} else {
+		hash = seed + xxhPrime64x5
+	}
+	return xxh64Finalize(hash+length, str)
+}
+
+// XXH64SeedString is the same as XXH64Seed for string `str`, which hashes `str` without copying it.
+func XXH64SeedString(seed uint64, str string) uint64 {
+	return XXH64Seed(seed, stringBytes(str))
+}
+
+// xxh64Lanes returns the initial accumulator lanes for `seed`.
+func xxh64Lanes(seed uint64) [4]uint64 {
+	return [4]uint64{
+		seed + xxhPrime64x1 + xxhPrime64x2,
+		seed + xxhPrime64x2,
+		seed,
+		seed - xxhPrime64x1,
+	}
+}
+
+// xxh64Stripes consumes all the whole 32 bytes stripes of `str` into lanes `v`,
+// and returns the remaining bytes.
+func xxh64Stripes(v *[4]uint64, str []byte) []byte {
+	for ; len(str) >= 32; str = str[32:] {
+		v[0] = xxh64Round(v[0], binary.LittleEndian.Uint64(str))
+		v[1] = xxh64Round(v[1], binary.LittleEndian.Uint64(str[8:]))
+		v[2] = xxh64Round(v[2], binary.LittleEndian.Uint64(str[16:]))
+		v[3] = xxh64Round(v[3], binary.LittleEndian.Uint64(str[24:]))
+	}
+	return str
+}
+
+// xxh64MergeLanes converges the accumulator lanes `v` into a single hash value.
+func xxh64MergeLanes(v *[4]uint64) uint64 {
+	hash := bits.RotateLeft64(v[0], 1) + bits.RotateLeft64(v[1], 7) +
+		bits.RotateLeft64(v[2], 12) + bits.RotateLeft64(v[3], 18)
+	for _, lane := range v {
+		hash ^= xxh64Round(0, lane)
+		hash = hash*xxhPrime64x1 + xxhPrime64x4
+	}
+	return hash
+}
+
+// xxh64Finalize mixes the remaining bytes, which are fewer than 32, into `hash` and avalanches it.
+func xxh64Finalize(hash uint64, str []byte) uint64 {
+	for ; len(str) >= 8; str = str[8:] {
+		hash ^= xxh64Round(0, binary.LittleEndian.Uint64(str))
+		hash = bits.RotateLeft64(hash, 27)*xxhPrime64x1 + xxhPrime64x4
+	}
+	if len(str) >= 4 {
+		hash ^= uint64(binary.LittleEndian.Uint32(str)) * xxhPrime64x1
+		hash = bits.RotateLeft64(hash, 23)*xxhPrime64x2 + xxhPrime64x3
+		str = str[4:]
+	}
+	for _, b := range str {
+		hash ^= uint64(b) * xxhPrime64x5
+		hash = bits.RotateLeft64(hash, 11) * xxhPrime64x1
+	}
+	return xxh64Avalanche(hash)
+}
+
+// xxh64Round mixes one 8 bytes `input` into accumulator `acc`.
+func xxh64Round(acc, input uint64) uint64 {
+	acc += input * xxhPrime64x2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxhPrime64x1
+}
+
+// xxh64Avalanche is the final mix of xxHash64.
+func xxh64Avalanche(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= xxhPrime64x2
+	hash ^= hash >> 29
+	hash *= xxhPrime64x3
+	hash ^= hash >> 32
+	return hash
+}
+
+type xxh64 struct {
+	seed   uint64
+	v      [4]uint64
+	mem    [32]byte // Pending bytes that do not fill a whole stripe yet.
+	n      int      // Number of pending bytes in mem.
+	length uint64   // Total number of written bytes.
+}
+
+// NewXXH64 returns a new hash.Hash64 computing the xxHash64 hash algorithm with seed 0.
+// Its Sum64 result is identical to XXH64 for the same written data.
+func NewXXH64() hash.Hash64 {
+	return NewXXH64Seed(0)
+}
+
+// NewXXH64Seed returns a new hash.Hash64 computing the xxHash64 hash algorithm with given `seed`.
+// Its Sum64 result is identical to XXH64Seed for the same written data.
+func NewXXH64Seed(seed uint64) hash.Hash64 {
+	return &xxh64{seed: seed, v: xxh64Lanes(seed)}
+}
+
+func (s *xxh64) Reset() { *s = xxh64{seed: s.seed, v: xxh64Lanes(s.seed)} }
+
+func (s *xxh64) Sum64() uint64 {
+	var hash uint64
+	if s.length >= 32 {
+		v := s.v
+		hash = xxh64MergeLanes(&v)
+	} else {
+		hash = s.seed + xxhPrime64x5
+	}
+	return xxh64Finalize(hash+s.length, s.mem[:s.n])
+}
+
+func (s *xxh64) Write(data []byte) (int, error) {
+	length := len(data)
+	s.length += uint64(length)
+	if s.n > 0 {
+		c := copy(s.mem[s.n:], data)
+		s.n += c
+		data = data[c:]
+		if s.n < len(s.mem) {
+			return length, nil
+		}
+		xxh64Stripes(&s.v, s.mem[:])
+		s.n = 0
+	}
+	data = xxh64Stripes(&s.v, data)
+	s.n = copy(s.mem[:], data)
+	return length, nil
+}
+
+func (s *xxh64) Size() int { return 8 }
+
+func (s *xxh64) BlockSize() int { return 32 }
+
+func (s *xxh64) Sum(in []byte) []byte { return binary.BigEndian.AppendUint64(in, s.Sum64()) }
@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minhash_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/focela/min/encoding/minhash"
+)
+
+func Test_XXH64_Vectors(t *testing.T) {
+	// The vectors of the reference implementation of xxHash64.
+	const s63 = "Call me Ishmael. Some years ago--never mind how long precisely-"
+	for _, v := range []struct {
+		seed uint64
+		str  string
+		want uint64
+	}{
+		{0, "", 0xef46db3751d8e999},
+		{0, "a", 0xd24ec4f1a98c6e5b},
+		{0, "as", 0x1c330fb2d66be179},
+		{0, "asd", 0x631c37ce72a97393},
+		{0, "asdf", 0x415872f599cea71e},
+		{0, s63, 0x02a2e85470d6fd96},
+		{123, "", 0xe0db84de91f3e198},
+		{math.MaxUint64, "asdf", 0x9a2fd8473be539b6},
+		{54321, s63, 0x1736d186daf5d1cd},
+	} {
+		if got := minhash.XXH64Seed(v.seed, []byte(v.str)); got != v.want {
+			t.Errorf("XXH64Seed(%d, %q) = %#x, want %#x", v.seed, v.str, got, v.want)
+		}
+		h := minhash.NewXXH64Seed(v.seed)
+		h.Write([]byte(v.str))
+		if got := h.Sum64(); got != v.want {
+			t.Errorf("NewXXH64Seed(%d) of %q = %#x, want %#x", v.seed, v.str, got, v.want)
+		}
+	}
+}
@@ -110,6 +110,7 @@ func WrapCodeWithSkipf(code mincode.Code, skip int, err error, format string, ar
 
 // Code returns the error code of current error.
 // It returns `CodeNil` if it has no error code, or it does not implement interface Code.
+// For an error wrapping multiple errors, it returns the first error code in depth-first order.
 func Code(err error) mincode.Code {
 	if err == nil {
 		return mincode.CodeNil
@@ -120,19 +121,21 @@ func Code(err error) mincode.Code {
 	if e, ok := err.(Unwrapper); ok {
 		return Code(e.Unwrap())
 	}
+	if e, ok := err.(MultiUnwrapper); ok {
+		for _, v := range e.Unwrap() {
+			if code := Code(v); code != mincode.CodeNil {
+				return code
+			}
+		}
+	}
 	return mincode.CodeNil
 }
 
-// HasCode checks and reports whether `err` has `code` in its chaining errors.
+// HasCode checks and reports whether `err` has `code` in its chaining errors,
+// including all the errors wrapped by errors wrapping multiple errors.
 func HasCode(err error, code mincode.Code) bool {
-	if err == nil {
-		return false
-	}
-	if e, ok := err.(CodeRetriever); ok {
-		return code == e.Code()
-	}
-	if e, ok := err.(Unwrapper); ok {
-		return HasCode(e.Unwrap(), code)
-	}
-	return false
+	return Find(err, func(err error) bool {
+		e, ok := err.(CodeRetriever)
+		return ok && code == e.Code()
+	}) != nil
 }
@@ -0,0 +1,62 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror
+
+import (
+	"github.com/focela/min/errors/mincode"
+)
+
+// Field creates and returns a field of `key` and `value` for WithFields.
+func Field(key string, value interface{}) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// WithFields attaches `fields` to `err`, and returns the error with the fields.
+// If `err` is an Error, it returns a copy of it with the fields appended, which keeps its text,
+// code, stack and wrapped error, and `err` itself is not changed.
+// Otherwise, it wraps `err` with the fields, which keeps the error string of `err` and is printed
+// with `err` as the same error by Stack, and the error code of `err` is retrieved through the wrapping.
+// It returns nil if `err` is nil.
+func WithFields(err error, fields ...KeyValue) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok && e != nil {
+		result := *e
+		result.fields = append(append([]KeyValue(nil), e.fields...), fields...)
+		return &result
+	}
+	return &Error{
+		error:  err,
+		stack:  callers(),
+		code:   mincode.CodeNil,
+		fields: append([]KeyValue(nil), fields...),
+	}
+}
+
+// Fields returns the fields of `err` and all its chaining errors merged into a map.
+// If the same key is attached at different levels, the outermost one takes precedence.
+// For an error wrapping multiple errors, the fields of its wrapped errors are merged in depth-first order.
+// It returns nil if there is no field.
+func Fields(err error) map[string]interface{} {
+	var result map[string]interface{}
+	Find(err, func(err error) bool {
+		e, ok := err.(*Error)
+		if !ok || e == nil {
+			return false
+		}
+		for _, kv := range e.fields {
+			if result == nil {
+				result = make(map[string]interface{})
+			}
+			if _, ok = result[kv.Key]; !ok {
+				result[kv.Key] = kv.Value
+			}
+		}
+		return false
+	})
+	return result
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+func Test_WithFields(t *testing.T) {
+	var (
+		coded  = minerror.NewCode(mincode.CodeNotFound, "user missing")
+		result = minerror.WithFields(coded, minerror.Field("user", 42))
+	)
+	if result.Error() != coded.Error() || minerror.Code(result) != mincode.CodeNotFound {
+		t.Fatalf("WithFields changes the error: %v", result)
+	}
+	if minerror.Fields(coded) != nil {
+		t.Fatal("WithFields changes the original error")
+	}
+	if got := minerror.Fields(result); !reflect.DeepEqual(got, map[string]interface{}{"user": 42}) {
+		t.Fatalf("Fields = %v", got)
+	}
+	if minerror.WithFields(nil, minerror.Field("k", 1)) != nil {
+		t.Fatal("WithFields(nil) is not nil")
+	}
+}
+
+func Test_WithFields_Foreign(t *testing.T) {
+	var (
+		inner  = fmt.Errorf("load: %w", minerror.NewCode(mincode.CodeNotFound, "user missing"))
+		result = minerror.WithFields(inner, minerror.Field("k", 1))
+	)
+	if result.Error() != inner.Error() {
+		t.Fatalf("Error = %q, want %q", result.Error(), inner.Error())
+	}
+	if minerror.Code(result) != mincode.CodeNotFound || !errors.Is(result, inner) {
+		t.Fatal("the wrapped error is not reached")
+	}
+	// The wrapper is printed with the wrapped error instead of as a separate error.
+	stack := fmt.Sprintf("%+s", minerror.WithFields(errors.New("boom"), minerror.Field("k", 1)))
+	if !strings.HasPrefix(stack, "1. boom {k=1}\n") || strings.Contains(stack, "2. ") {
+		t.Fatalf("Stack = %q", stack)
+	}
+}
+
+func Test_Fields_Precedence(t *testing.T) {
+	var (
+		inner = minerror.WithFields(errors.New("inner"), minerror.Field("k", "inner"), minerror.Field("a", 1))
+		outer = minerror.WithFields(minerror.Wrap(inner, "outer"), minerror.Field("k", "outer"))
+		multi = minerror.Join(outer, minerror.WithFields(errors.New("other"), minerror.Field("b", 2)))
+	)
+	want := map[string]interface{}{"k": "outer", "a": 1, "b": 2}
+	if got := minerror.Fields(multi); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Fields = %v, want %v", got, want)
+	}
+	if minerror.Fields(errors.New("x")) != nil {
+		t.Fatal("Fields of an error without fields is not nil")
+	}
+}
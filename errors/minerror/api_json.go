@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/internal/errors"
+)
+
+// JSONMode is the mode that marshaling errors to JSON.
+type JSONMode = errors.JSONMode
+
+const (
+	// JSONModeText marshals errors to their error strings, with their fields if any. It is the default mode.
+	JSONModeText = errors.JSONModeText
+
+	// JSONModeDetail marshals errors to structured objects as Encode.
+	JSONModeDetail = errors.JSONModeDetail
+)
+
+// JSONDetail is the code detail of JSON object or array decoded by Decode, which is the compact JSON text.
+// It is a string so that the decoded error codes are comparable with == and HasCode,
+// the codes decoded from the same JSON are equal, and it is marshaled to JSON as the JSON text itself.
+type JSONDetail string
+
+// NewJSONDetail creates and returns the JSONDetail of `value`, which is marshaled with json.Marshal,
+// so that the keys of maps are sorted. It returns empty JSONDetail if `value` cannot be marshaled.
+func NewJSONDetail(value interface{}) JSONDetail {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return JSONDetail(data)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (d JSONDetail) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return []byte(d), nil
+}
+
+// Value returns the value of the JSON text unmarshaled by json.Unmarshal, eg: map[string]interface{}.
+func (d JSONDetail) Value() interface{} {
+	var value interface{}
+	_ = json.Unmarshal([]byte(d), &value)
+	return value
+}
+
+// SetJSONMode sets the JSON mode of MarshalJSON of the errors in this package.
+// It can also be configured by the command option or environment variable "min.error.json.mode".
+// It should be called in the boot procedure, as it is not safe for concurrent use.
+func SetJSONMode(mode JSONMode) {
+	errors.SetJSONMode(mode)
+}
+
+// Encode marshals `err` and its chaining errors to the structured JSON form,
+// which contains the message, code number, code message, code detail, fields and stack frames
+// of each error, and its wrapped errors, eg:
+// {"error":"load failed: user missing","message":"load failed","code":65,"codeMessage":"Not Found",
+// "frames":[{"function":"main.load","file":"/app/main.go","line":16}],"wrapped":{"message":"user missing",...}}.
+// The stack frames are filtered the same as Stack.
+// It returns "null" if `err` is nil.
+func Encode(err error) ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+	var (
+		index int
+		infos = appendStackInfos(nil, err, "", 0, errors.IsStackModeBrief())
+	)
+	filterLinesOfStackInfos(infos)
+	root := encodeError(err, infos, &index)
+	root.Error = err.Error()
+	return json.Marshal(root)
+}
+
+// Decode reconstructs an Error from `data`, which is produced by Encode or MarshalJSON,
+// so that errors can be passed across service boundaries.
+// The code, fields and wrapped errors are restored, and the stack of the returned error
+// is the stack frames in `data` from the remote origin, which are printed by Stack as they are.
+// The code detail of JSON object or array is decoded as JSONDetail, so that the codes stay comparable.
+// A plain JSON string is decoded as an error with the string as its text, and an error wrapping
+// multiple errors is decoded as a MultiError, which is wrapped by the returned Error without text.
+// It returns nil if `data` is JSON null, which is produced by Encode for nil error.
+func Decode(data []byte) (*Error, error) {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return &Error{text: text, code: mincode.CodeNil}, nil
+	}
+	item := &jsonError{}
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, Wrap(err, "invalid JSON data for error")
+	}
+	if item.Code == nil {
+		// The text form with fields, eg: {"error":"...","fields":{"user":1}}.
+		return &Error{text: item.Error, code: mincode.CodeNil, fields: keyValuesOf(item.Fields)}, nil
+	}
+	switch result := decodeError(item).(type) {
+	case *Error:
+		return result, nil
+	default:
+		return &Error{error: result, code: mincode.CodeNil}, nil
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+func Test_Encode_Decode(t *testing.T) {
+	var (
+		detailCode = mincode.New(1001, "quota exceeded", map[string]interface{}{"limit": 10, "used": []int{4, 6}})
+		coded      = minerror.NewCode(mincode.CodeNotFound, "user missing")
+	)
+	for _, c := range []struct {
+		name string
+		err  error
+	}{
+		{"text", minerror.New("plain")},
+		{"code", coded},
+		{"wrapped", minerror.Wrap(minerror.Wrap(coded, "load"), "handler")},
+		{"code detail", minerror.NewCode(detailCode, "over quota")},
+		{"fields", minerror.WithFields(minerror.Wrap(coded, "load"), minerror.Field("user", "42"))},
+		{"foreign", errors.New("foreign")},
+		{"foreign wrapper", minerror.Wrap(fmt.Errorf("read: %w", coded), "load")},
+		{"foreign fields", minerror.WithFields(fmt.Errorf("read: %w", coded), minerror.Field("k", true))},
+		{"joined", minerror.Join(coded, minerror.Wrap(errors.New("b"), "c"))},
+		{"nested joined", minerror.Wrap(minerror.Join(errors.New("a"), minerror.Join(coded)), "outer")},
+	} {
+		data, err := minerror.Encode(c.err)
+		if err != nil {
+			t.Fatalf("%s: Encode failed: %v", c.name, err)
+		}
+		decoded, err := minerror.Decode(data)
+		if err != nil {
+			t.Fatalf("%s: Decode failed: %v", c.name, err)
+		}
+		if decoded.Error() != c.err.Error() {
+			t.Errorf("%s: Error = %q, want %q", c.name, decoded.Error(), c.err.Error())
+		}
+		if got, want := minerror.Code(decoded), minerror.Code(c.err); got.Code() != want.Code() || got.Message() != want.Message() {
+			t.Errorf("%s: Code = %v, want %v", c.name, got, want)
+		}
+		if got, want := minerror.Fields(decoded), minerror.Fields(c.err); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: Fields = %v, want %v", c.name, got, want)
+		}
+		// The decoded error is encoded to the same data, as its stack is the decoded stack frames.
+		var result error = decoded
+		if _, ok := c.err.(*minerror.MultiError); ok {
+			if result, ok = decoded.Unwrap().(*minerror.MultiError); !ok {
+				t.Fatalf("%s: Decode = %T, want MultiError wrapped", c.name, decoded.Unwrap())
+			}
+		}
+		again, err := minerror.Encode(result)
+		if err != nil || string(again) != string(data) {
+			t.Errorf("%s: Encode of decoded error = %s, want %s", c.name, again, data)
+		}
+	}
+}
+
+func Test_Decode_DetailComparable(t *testing.T) {
+	data, err := minerror.Encode(minerror.NewCode(mincode.New(1001, "quota", map[string]interface{}{"limit": 10}), "x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1, _ := minerror.Decode(data)
+	d2, _ := minerror.Decode(data)
+	if minerror.Code(d1) != minerror.Code(d2) || !minerror.HasCode(d1, minerror.Code(d2)) {
+		t.Fatal("codes decoded from the same data are not equal")
+	}
+	detail, ok := minerror.Code(d1).Detail().(minerror.JSONDetail)
+	if !ok || detail != `{"limit":10}` {
+		t.Fatalf("Detail = %#v", minerror.Code(d1).Detail())
+	}
+	if !reflect.DeepEqual(detail.Value(), map[string]interface{}{"limit": float64(10)}) {
+		t.Fatalf("Detail value = %v", detail.Value())
+	}
+}
+
+func Test_Decode_Null(t *testing.T) {
+	data, err := minerror.Encode(nil)
+	if err != nil || string(data) != "null" {
+		t.Fatalf("Encode(nil) = %s, %v", data, err)
+	}
+	if decoded, err := minerror.Decode(data); decoded != nil || err != nil {
+		t.Fatalf("Decode(null) = %v, %v", decoded, err)
+	}
+	if _, err = minerror.Decode([]byte("{")); err == nil {
+		t.Fatal("Decode of invalid data succeeds")
+	}
+}
+
+func Test_JSONMode(t *testing.T) {
+	var (
+		coded  = minerror.NewCode(mincode.CodeNotFound, "user missing")
+		fields = minerror.WithFields(coded, minerror.Field("user", 42))
+	)
+	if data, _ := json.Marshal(coded); string(data) != `"user missing"` {
+		t.Fatalf("text mode = %s", data)
+	}
+	if data, _ := json.Marshal(fields); string(data) != `{"error":"user missing","fields":{"user":42}}` {
+		t.Fatalf("text mode with fields = %s", data)
+	}
+	var decoded minerror.Error
+	if data, _ := json.Marshal(fields); json.Unmarshal(data, &decoded) != nil ||
+		decoded.Error() != "user missing" || minerror.Fields(&decoded)["user"] != float64(42) {
+		t.Fatalf("UnmarshalJSON of text mode = %v %v", decoded.Error(), minerror.Fields(&decoded))
+	}
+
+	minerror.SetJSONMode(minerror.JSONModeDetail)
+	defer minerror.SetJSONMode(minerror.JSONModeText)
+	for _, err := range []error{fields, minerror.Join(coded, errors.New("x"))} {
+		data, _ := json.Marshal(err)
+		want, _ := minerror.Encode(err)
+		if string(data) != string(want) {
+			t.Fatalf("detail mode = %s, want %s", data, want)
+		}
+	}
+}
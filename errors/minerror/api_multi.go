@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror
+
+// Append appends `errs` to `err`, and returns a MultiError of all the non-nil errors.
+// The errors of MultiError in `err` and `errs` are flattened into the result, and the stack
+// of `err` is kept if it is a MultiError. It returns nil if there is no non-nil error.
+func Append(err error, errs ...error) error {
+	var (
+		result = &MultiError{}
+		all    = append([]error{err}, errs...)
+	)
+	if e, ok := err.(*MultiError); ok && e != nil {
+		result.stack = e.stack
+	} else {
+		result.stack = callers()
+	}
+	for _, e := range all {
+		if m, ok := e.(*MultiError); ok {
+			if m != nil {
+				result.errors = append(result.errors, m.errors...)
+			}
+		} else if e != nil {
+			result.errors = append(result.errors, e)
+		}
+	}
+	if len(result.errors) == 0 {
+		return nil
+	}
+	return result
+}
+
+// Join returns a MultiError of the non-nil errors of `errs`, like errors.Join of the standard library.
+// Unlike Append, the errors are kept as they are without flattening.
+// It returns nil if there is no non-nil error.
+func Join(errs ...error) error {
+	result := &MultiError{stack: callers()}
+	for _, e := range errs {
+		if e != nil {
+			result.errors = append(result.errors, e)
+		}
+	}
+	if len(result.errors) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+func Test_Join(t *testing.T) {
+	var (
+		a = errors.New("a")
+		b = minerror.NewCode(mincode.CodeNotFound, "b")
+	)
+	if minerror.Join() != nil || minerror.Join(nil, nil) != nil {
+		t.Fatal("Join of no error is not nil")
+	}
+	joined := minerror.Join(a, nil, b)
+	if want := errors.Join(a, b).Error(); joined.Error() != want {
+		t.Fatalf("Error = %q, want %q", joined.Error(), want)
+	}
+	// The nested MultiError is kept by Join.
+	nested := minerror.Join(joined, errors.New("c"))
+	if errs := nested.(*minerror.MultiError).Errors(); len(errs) != 2 || errs[0] != joined {
+		t.Fatalf("Errors = %v", errs)
+	}
+	if minerror.Code(nested) != mincode.CodeNotFound || minerror.Cause(nested) != a {
+		t.Fatalf("Code = %v, Cause = %v", minerror.Code(nested), minerror.Cause(nested))
+	}
+}
+
+func Test_Append(t *testing.T) {
+	var (
+		a = errors.New("a")
+		b = errors.New("b")
+		c = errors.New("c")
+	)
+	if minerror.Append(nil) != nil || minerror.Append(nil, nil) != nil {
+		t.Fatal("Append of no error is not nil")
+	}
+	// The MultiError in the arguments are flattened by Append.
+	var result error
+	for _, err := range []error{a, nil, minerror.Join(b, c)} {
+		result = minerror.Append(result, err)
+	}
+	errs := result.(*minerror.MultiError).Errors()
+	if len(errs) != 3 || errs[0] != a || errs[1] != b || errs[2] != c {
+		t.Fatalf("Errors = %v", errs)
+	}
+	// The returned errors are a copy.
+	errs[0] = nil
+	if result.(*minerror.MultiError).Errors()[0] != a {
+		t.Fatal("Errors returns the internal errors")
+	}
+}
+
+func Test_MultiError_Format(t *testing.T) {
+	err := minerror.Join(errors.New("a"), minerror.Wrap(errors.New("b"), "c"))
+	if got := fmt.Sprintf("%-v", err); got != "2 errors occurred" {
+		t.Fatalf("%%-v = %q", got)
+	}
+	stack := fmt.Sprintf("%+s", minerror.Wrap(err, "outer"))
+	for _, line := range []string{"1. outer\n", "2. 2 errors occurred\n", "   2.1.1. a\n", "   2.2.1. c\n", "   2.2.2. b\n"} {
+		if !strings.Contains(stack, line) {
+			t.Fatalf("Stack does not contain %q:\n%s", line, stack)
+		}
+	}
+}
@@ -11,19 +11,21 @@ import (
 
 // Option represents the options for creating a custom error.
 type Option struct {
-	Error error        // Wrapped error, if any.
-	Stack bool         // Whether to record stack information into the error.
-	Text  string       // Error message, used in New* functions.
-	Code  mincode.Code // Error code, if applicable.
+	Error  error        // Wrapped error, if any.
+	Stack  bool         // Whether to record stack information into the error.
+	Text   string       // Error message, used in New* functions.
+	Code   mincode.Code // Error code, if applicable.
+	Fields []KeyValue   // Structured fields attached to the error, if any.
 }
 
 // NewErrorWithOption creates and returns a custom error using the provided options.
 // This function is primarily used for internal error handling within the framework.
 func NewErrorWithOption(option Option) error {
 	err := &Error{
-		error: option.Error,
-		text:  option.Text,
-		code:  option.Code,
+		error:  option.Error,
+		text:   option.Text,
+		code:   option.Code,
+		fields: option.Fields,
 	}
 	// Record stack trace if Stack is set to true.
 	if option.Stack {
@@ -6,6 +6,7 @@
 package minerror
 
 import (
+	"errors"
 	"runtime"
 )
 
@@ -18,6 +19,7 @@ const (
 )
 
 // Cause returns the root cause error of `err`.
+// For an error wrapping multiple errors, it returns the root cause error of the first one.
 func Cause(err error) error {
 	if err == nil {
 		return nil
@@ -28,11 +30,16 @@ func Cause(err error) error {
 	if e, ok := err.(Unwrapper); ok {
 		return Cause(e.Unwrap())
 	}
+	if e, ok := err.(MultiUnwrapper); ok {
+		return causeOfMulti(e)
+	}
 	return err
 }
 
 // Stack returns the stack callers as a string.
 // It returns the error string directly if the `err` does not support stack tracing.
+// For an error wrapping multiple errors, or an error not of this package wrapping errors with stacks,
+// it returns the stacks of all the wrapped errors.
 func Stack(err error) string {
 	if err == nil {
 		return ""
@@ -40,6 +47,12 @@ func Stack(err error) string {
 	if e, ok := err.(StackTracer); ok {
 		return e.Stack()
 	}
+	if _, ok := err.(MultiUnwrapper); ok {
+		return stackOf(err)
+	}
+	if _, ok := err.(Unwrapper); ok && Find(err, HasStack) != nil {
+		return stackOf(err)
+	}
 	return err.Error()
 }
 
@@ -89,12 +102,60 @@ func Equal(err, target error) bool {
 }
 
 // Is reports whether the current error `err` has error `target` in its chaining errors.
-// This is an implementation of the standard library's errors.Is from Go version 1.17.
+// It is the same as errors.Is of the standard library, which traverses the error tree of `err`
+// and calls the Is method of each error, so an Error matches the target of the same code and text.
 func Is(err, target error) bool {
-	if e, ok := err.(IsChecker); ok {
-		return e.Is(target)
+	return errors.Is(err, target)
+}
+
+// As finds the first error in the error tree of `err` that matches `target`, and if one is found,
+// sets `target` to that error value and returns true. Otherwise, it returns false.
+// It is the same as errors.As of the standard library, and it panics if `target` is not
+// a non-nil pointer to either a type that implements error, or to any interface type.
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
+
+// AsType finds the first error in the error tree of `err` that matches type T, and returns it.
+// It returns the zero value and false if no error matches.
+// T must be a type that implements error or an interface type, eg: AsType[*Error](err).
+func AsType[T any](err error) (T, bool) {
+	var target T
+	ok := errors.As(err, &target)
+	return target, ok
+}
+
+// Find returns the first error in the error tree of `err` for which `predicate` returns true,
+// or nil if there is none. The tree is traversed in pre-order depth-first, the same as errors.Is.
+func Find(err error, predicate func(err error) bool) error {
+	if err == nil {
+		return nil
 	}
-	return false
+	if predicate(err) {
+		return err
+	}
+	switch e := err.(type) {
+	case Unwrapper:
+		return Find(e.Unwrap(), predicate)
+	case MultiUnwrapper:
+		for _, v := range e.Unwrap() {
+			if found := Find(v, predicate); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// causeOfMulti returns the root cause error of the first non-nil error wrapped by `err`,
+// or `err` itself if it wraps no error.
+func causeOfMulti(err MultiUnwrapper) error {
+	for _, e := range err.Unwrap() {
+		if e != nil {
+			return Cause(e)
+		}
+	}
+	return err
 }
 
 // callers returns the program counters (addresses) for the current stack.
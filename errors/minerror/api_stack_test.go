@@ -0,0 +1,202 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+// customIsError matches any target of the same kind by its Is method.
+type customIsError struct {
+	kind string
+}
+
+func (e customIsError) Error() string { return "custom " + e.kind }
+
+func (e customIsError) Is(target error) bool {
+	t, ok := target.(customIsError)
+	return ok && t.kind == e.kind
+}
+
+// customAsError converts itself to *fs.PathError by its As method.
+type customAsError struct{}
+
+func (e *customAsError) Error() string { return "custom as" }
+
+func (e *customAsError) As(target interface{}) bool {
+	if t, ok := target.(**fs.PathError); ok {
+		*t = &fs.PathError{Op: "as", Path: "custom", Err: fs.ErrNotExist}
+		return true
+	}
+	return false
+}
+
+// foreignMulti wraps multiple errors without being MultiError.
+type foreignMulti struct {
+	errs []error
+}
+
+func (e *foreignMulti) Error() string { return fmt.Sprintf("%d foreign errors", len(e.errs)) }
+
+func (e *foreignMulti) Unwrap() []error { return e.errs }
+
+// stackCase is an error tree for the compatibility tests against the standard library.
+type stackCase struct {
+	name string
+	err  error
+}
+
+func stackCases() []stackCase {
+	var (
+		coded    = minerror.NewCode(mincode.CodeNotFound, "user missing")
+		pathErr  = &fs.PathError{Op: "open", Path: "/x", Err: os.ErrNotExist}
+		customIs = customIsError{kind: "a"}
+	)
+	return []stackCase{
+		{"nil", nil},
+		{"plain", errors.New("plain")},
+		{"minerror", coded},
+		{"wrapped", minerror.Wrap(minerror.Wrap(pathErr, "read"), "load")},
+		{"wrapped code", minerror.WrapCode(mincode.CodeInternalError, coded, "handler")},
+		{"joined", minerror.Join(errors.New("first"), minerror.Wrap(pathErr, "read"))},
+		{"appended", minerror.Append(minerror.Join(coded), customIs, nil)},
+		{"std joined", errors.Join(coded, minerror.Wrap(customIs, "custom"))},
+		{"foreign wrapper", fmt.Errorf("load: %w", minerror.Wrap(pathErr, "read"))},
+		{"foreign multi wrapper", fmt.Errorf("load: %w and %w", coded, pathErr)},
+		{"foreign multi", &foreignMulti{errs: []error{errors.New("x"), minerror.Wrap(customIs, "y")}}},
+		{"nested", minerror.Wrap(fmt.Errorf("a: %w", minerror.Join(errors.New("b"), fmt.Errorf("c: %w", coded))), "d")},
+		{"custom is", minerror.Wrap(customIs, "wrapped")},
+		{"custom as", minerror.Wrap(&customAsError{}, "wrapped")},
+		{"fields", minerror.WithFields(fmt.Errorf("x: %w", pathErr), minerror.Field("k", 1))},
+	}
+}
+
+func stackTargets() []error {
+	return []error{
+		nil,
+		os.ErrNotExist,
+		fs.ErrPermission,
+		customIsError{kind: "a"},
+		customIsError{kind: "b"},
+		minerror.NewCode(mincode.CodeNotFound, "user missing"),
+		minerror.NewCode(mincode.CodeNotFound, "other"),
+	}
+}
+
+func Test_Is(t *testing.T) {
+	for _, c := range stackCases() {
+		for i, target := range stackTargets() {
+			if got, want := minerror.Is(c.err, target), errors.Is(c.err, target); got != want {
+				t.Errorf("%s: Is(target %d) = %v, errors.Is = %v", c.name, i, got, want)
+			}
+		}
+		if !minerror.Is(c.err, c.err) {
+			t.Errorf("%s: Is(err, err) = false", c.name)
+		}
+	}
+}
+
+func Test_As(t *testing.T) {
+	for _, c := range stackCases() {
+		var (
+			got, want       *fs.PathError
+			gotErr, wantErr *minerror.Error
+			gotIf, wantIf   interface{ Code() mincode.Code }
+		)
+		if g, w := minerror.As(c.err, &got), errors.As(c.err, &want); g != w || !samePathError(got, want) {
+			t.Errorf("%s: As(*fs.PathError) = %v %v, errors.As = %v %v", c.name, g, got, w, want)
+		}
+		if g, w := minerror.As(c.err, &gotErr), errors.As(c.err, &wantErr); g != w || gotErr != wantErr {
+			t.Errorf("%s: As(*Error) = %v, errors.As = %v", c.name, g, w)
+		}
+		if g, w := minerror.As(c.err, &gotIf), errors.As(c.err, &wantIf); g != w || gotIf != wantIf {
+			t.Errorf("%s: As(interface) = %v, errors.As = %v", c.name, g, w)
+		}
+	}
+}
+
+func Test_As_InvalidTarget(t *testing.T) {
+	for name, target := range map[string]interface{}{
+		"nil":         nil,
+		"non-pointer": fs.PathError{},
+		"nil pointer": (*error)(nil),
+		"non-error":   new(int),
+	} {
+		if got, want := recoverOf(func() { minerror.As(errors.New("x"), target) }),
+			recoverOf(func() { errors.As(errors.New("x"), target) }); got != want {
+			t.Errorf("%s: As panics %q, errors.As panics %q", name, got, want)
+		}
+	}
+}
+
+func Test_AsType(t *testing.T) {
+	for _, c := range stackCases() {
+		var want *fs.PathError
+		got, ok := minerror.AsType[*fs.PathError](c.err)
+		if w := errors.As(c.err, &want); ok != w || !samePathError(got, want) {
+			t.Errorf("%s: AsType[*fs.PathError] = %v %v, errors.As = %v %v", c.name, ok, got, w, want)
+		}
+		var wantCustom customIsError
+		gotCustom, ok := minerror.AsType[customIsError](c.err)
+		if w := errors.As(c.err, &wantCustom); ok != w || gotCustom != wantCustom {
+			t.Errorf("%s: AsType[customIsError] = %v, errors.As = %v", c.name, ok, w)
+		}
+	}
+}
+
+func Test_Find(t *testing.T) {
+	for _, c := range stackCases() {
+		for i, target := range stackTargets() {
+			if target == nil {
+				continue
+			}
+			// Find with the predicate of Is matches the same as errors.Is for the targets without Is methods
+			// in the tree, as both traverse in pre-order depth-first.
+			found := minerror.Find(c.err, func(err error) bool {
+				if err == target {
+					return true
+				}
+				x, ok := err.(interface{ Is(error) bool })
+				return ok && x.Is(target)
+			})
+			if got, want := found != nil, errors.Is(c.err, target); got != want {
+				t.Errorf("%s: Find(target %d) = %v, errors.Is = %v", c.name, i, got, want)
+			}
+		}
+		if minerror.Find(c.err, func(error) bool { return false }) != nil {
+			t.Errorf("%s: Find(false) is not nil", c.name)
+		}
+		if found := minerror.Find(c.err, func(error) bool { return true }); found != c.err {
+			t.Errorf("%s: Find(true) = %v, want the root error", c.name, found)
+		}
+	}
+}
+
+// samePathError reports whether `a` and `b` are the same *fs.PathError or equal by value.
+func samePathError(a, b *fs.PathError) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// recoverOf returns the recovered panic message of `f`, or empty string if it does not panic.
+func recoverOf(f func()) (message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			message = fmt.Sprint(r)
+		}
+	}()
+	f()
+	return ""
+}
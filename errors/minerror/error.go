@@ -60,11 +60,20 @@ type Unwrapper interface {
 	Unwrap() error
 }
 
+// MultiUnwrapper defines an interface for unwrapping an error to multiple underlying errors,
+// which is implemented by MultiError and the errors of errors.Join.
+type MultiUnwrapper interface {
+	Error() string
+	Unwrap() []error
+}
+
 type Error struct {
-	error error        // Wrapped error.
-	stack stack        // Stack array, which records the stack information when this error is created or wrapped.
-	text  string       // Custom Error text when Error is created, might be empty when its code is not nil.
-	code  mincode.Code // Error code if necessary.
+	error  error        // Wrapped error.
+	stack  stack        // Stack array, which records the stack information when this error is created or wrapped.
+	text   string       // Custom Error text when Error is created, might be empty when its code is not nil.
+	code   mincode.Code // Error code if necessary.
+	fields []KeyValue   // Structured fields attached to this error, in the order of attachment.
+	frames []*stackLine // Stack lines from the remote origin for the error decoded from JSON, which has no stack.
 }
 
 const (
@@ -115,6 +124,8 @@ func (err *Error) Cause() error {
 				currentErr = e
 			} else if e, ok := currentErr.error.(CauseRetriever); ok {
 				return e.Cause()
+			} else if e, ok := currentErr.error.(MultiUnwrapper); ok {
+				return causeOfMulti(e)
 			} else {
 				return currentErr.error
 			}
@@ -131,10 +142,12 @@ func (err *Error) BaseError() error {
 		return nil
 	}
 	return &Error{
-		error: nil,
-		stack: err.stack,
-		text:  err.text,
-		code:  err.code,
+		error:  nil,
+		stack:  err.stack,
+		text:   err.text,
+		code:   err.code,
+		fields: err.fields,
+		frames: err.frames,
 	}
 }
 
@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyValue is a structured key/value field attached to an error, eg: the user ID of a failed request.
+type KeyValue struct {
+	Key   string      // Key of the field.
+	Value interface{} // Value of the field.
+}
+
+// Fields returns the fields of the error and its chaining errors, which is the same as Fields(err).
+func (err *Error) Fields() map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	return Fields(err)
+}
+
+// String returns the field in "key=value" format.
+func (kv KeyValue) String() string {
+	return fmt.Sprintf("%s=%v", kv.Key, kv.Value)
+}
+
+// formatFields returns `fields` in "{key1=value1 key2=value2}" format, or empty string if there is no field.
+func formatFields(fields []KeyValue) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	items := make([]string, len(fields))
+	for i, kv := range fields {
+		items[i] = kv.String()
+	}
+	return "{" + strings.Join(items, " ") + "}"
+}
@@ -5,11 +5,254 @@
 
 package minerror
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/internal/errors"
+)
+
+// jsonError is the structured JSON form of an error and its chaining errors.
+type jsonError struct {
+	Error       string                 `json:"error,omitempty"`       // Error string of the whole chain, only for the top error.
+	Message     string                 `json:"message"`               // Error text of the current level.
+	Full        bool                   `json:"full,omitempty"`        // Whether Message contains the text of the wrapped error.
+	Code        *int                   `json:"code,omitempty"`        // Error code number, -1 if it has no error code.
+	CodeMessage string                 `json:"codeMessage,omitempty"` // Brief message of the error code.
+	Detail      *jsonDetail            `json:"detail,omitempty"`      // Detail of the error code.
+	Fields      map[string]interface{} `json:"fields,omitempty"`      // Fields attached to the current level.
+	Frames      []jsonFrame            `json:"frames,omitempty"`      // Stack frames of the current level.
+	Wrapped     *jsonError             `json:"wrapped,omitempty"`     // Wrapped error of the current level.
+	Errors      []*jsonError           `json:"errors,omitempty"`      // Wrapped errors of an error wrapping multiple errors.
+}
+
+// jsonDetail is the detail of an error code in the structured JSON form.
+// It is decoded to a comparable value, as error codes are compared with ==, see JSONDetail.
+type jsonDetail struct {
+	value interface{}
+}
+
+// remoteError is a decoded error not of this package, whose text contains the text of its wrapped error.
+type remoteError struct {
+	text  string // Error text, which contains the text of the wrapped error.
+	error error  // Wrapped error.
+}
+
+// jsonFrame is a stack frame in the structured JSON form.
+type jsonFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
 
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
+// It marshals the error string, or an object of the error string and the fields
+// of the error chain if there are fields, eg: {"error":"...","fields":{"user":1}}.
+// It marshals the structured form as Encode in JSONModeDetail mode.
 // Note: Using json.Marshal to safely handle escaping of special characters.
 func (err Error) MarshalJSON() ([]byte, error) {
-	// Use json.Marshal to handle escaping and serialization
-	return json.Marshal(err.Error())
+	if errors.IsJSONModeDetail() {
+		return Encode(&err)
+	}
+	fields := Fields(&err)
+	if len(fields) == 0 {
+		// Use json.Marshal to handle escaping and serialization
+		return json.Marshal(err.Error())
+	}
+	return json.Marshal(struct {
+		Error  string                 `json:"error"`
+		Fields map[string]interface{} `json:"fields"`
+	}{
+		Error:  err.Error(),
+		Fields: fields,
+	})
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+// It accepts all the forms produced by MarshalJSON, see Decode.
+func (err *Error) UnmarshalJSON(data []byte) error {
+	result, e := Decode(data)
+	if e != nil {
+		return e
+	}
+	if result != nil {
+		*err = *result
+	}
+	return nil
+}
+
+// encodeError converts `err` and its chaining errors to the structured JSON form.
+// The stack infos of `err` are produced in the same order as the traversal, and `index`
+// is the index of the stack info of `err` in `infos`.
+func encodeError(err error, infos []*stackInfo, index *int) *jsonError {
+	var (
+		root    *jsonError
+		current **jsonError = &root
+	)
+	for err != nil {
+		var (
+			item = &jsonError{Frames: jsonFramesOf(infos[*index].Lines)}
+			code = Code(err)
+		)
+		*index++
+		*current = item
+		current = &item.Wrapped
+		switch e := err.(type) {
+		case *Error:
+			item.Message, code = e.text, e.code
+			if code == nil {
+				code = mincode.CodeNil
+			}
+			if len(e.fields) > 0 {
+				item.Fields = make(map[string]interface{}, len(e.fields))
+				for _, kv := range e.fields {
+					item.Fields[kv.Key] = kv.Value
+				}
+			}
+			err = e.error
+
+		case MultiUnwrapper:
+			item.Message = e.Error()
+			for _, v := range e.Unwrap() {
+				if v != nil {
+					item.Errors = append(item.Errors, encodeError(v, infos, index))
+				}
+			}
+			err = nil
+
+		case Unwrapper:
+			item.Message, item.Full = err.Error(), true
+			err = e.Unwrap()
+
+		default:
+			item.Message = err.Error()
+			err = nil
+		}
+		number := code.Code()
+		item.Code, item.CodeMessage = &number, code.Message()
+		if detail := code.Detail(); detail != nil {
+			item.Detail = &jsonDetail{value: detail}
+		}
+	}
+	return root
+}
+
+// decodeError reconstructs the error from the structured JSON form `item`.
+// The stack frames are kept as remote stack lines, as they are not from the current process.
+func decodeError(item *jsonError) error {
+	var wrapped error
+	if item.Wrapped != nil {
+		wrapped = decodeError(item.Wrapped)
+	}
+	var (
+		code   mincode.Code = mincode.CodeNil
+		detail interface{}
+	)
+	if item.Detail != nil {
+		detail = item.Detail.value
+	}
+	if item.Code != nil && (*item.Code != code.Code() || item.CodeMessage != "" || detail != nil) {
+		code = mincode.New(*item.Code, item.CodeMessage, detail)
+	}
+	if item.Full && wrapped != nil {
+		return &remoteError{text: item.Message, error: wrapped}
+	}
+	if len(item.Errors) > 0 {
+		multi := &MultiError{frames: stackLinesOf(item.Frames)}
+		for _, v := range item.Errors {
+			if v != nil {
+				multi.errors = append(multi.errors, decodeError(v))
+			}
+		}
+		return multi
+	}
+	return &Error{
+		error:  wrapped,
+		text:   item.Message,
+		code:   code,
+		fields: keyValuesOf(item.Fields),
+		frames: stackLinesOf(item.Frames),
+	}
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (d jsonDetail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.value)
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+// JSON objects and arrays are kept as JSONDetail, which is comparable unlike maps and slices,
+// and the other values are decoded as they are.
+func (d *jsonDetail) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		d.value = NewJSONDetail(value)
+	default:
+		d.value = value
+	}
+	return nil
+}
+
+// Error returns the error text.
+func (err *remoteError) Error() string {
+	return err.text
+}
+
+// Unwrap returns the wrapped error.
+func (err *remoteError) Unwrap() error {
+	return err.error
+}
+
+// jsonFramesOf converts stack `lines` to frames of the structured JSON form.
+func jsonFramesOf(lines []*stackLine) []jsonFrame {
+	if len(lines) == 0 {
+		return nil
+	}
+	frames := make([]jsonFrame, len(lines))
+	for i, line := range lines {
+		frames[i] = jsonFrame{Function: line.Function, File: line.FileLine}
+		if pos := strings.LastIndexByte(line.FileLine, ':'); pos >= 0 {
+			if n, err := strconv.Atoi(line.FileLine[pos+1:]); err == nil {
+				frames[i].File, frames[i].Line = line.FileLine[:pos], n
+			}
+		}
+	}
+	return frames
+}
+
+// stackLinesOf converts `frames` of the structured JSON form to stack lines.
+func stackLinesOf(frames []jsonFrame) []*stackLine {
+	if len(frames) == 0 {
+		return nil
+	}
+	lines := make([]*stackLine, len(frames))
+	for i, frame := range frames {
+		lines[i] = &stackLine{Function: frame.Function, FileLine: frame.File}
+		if frame.Line > 0 {
+			lines[i].FileLine += ":" + strconv.Itoa(frame.Line)
+		}
+	}
+	return lines
+}
+
+// keyValuesOf converts `fields` to key/values sorted by their keys.
+func keyValuesOf(fields map[string]interface{}) []KeyValue {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]KeyValue, 0, len(fields))
+	for key, value := range fields {
+		result = append(result, KeyValue{Key: key, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
 }
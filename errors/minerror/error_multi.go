@@ -0,0 +1,128 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package minerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/internal/errors"
+)
+
+// MultiError is an error which aggregates multiple errors.
+// It implements Unwrap() []error, so that errors.Is and errors.As of the standard library
+// traverse all its errors, and so do Code, HasCode, Cause and Stack of this package.
+type MultiError struct {
+	errors []error      // Aggregated errors, which are never nil.
+	stack  stack        // Stack array, which records the stack information when this error is created.
+	frames []*stackLine // Stack lines from the remote origin for the error decoded from JSON, which has no stack.
+}
+
+// Error returns the messages of all errors separated by newlines, the same as errors.Join.
+func (err *MultiError) Error() string {
+	if err == nil {
+		return ""
+	}
+	messages := make([]string, len(err.errors))
+	for i, e := range err.errors {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Errors returns a copy of the aggregated errors.
+func (err *MultiError) Errors() []error {
+	if err == nil {
+		return nil
+	}
+	return append([]error(nil), err.errors...)
+}
+
+// Unwrap returns the aggregated errors.
+func (err *MultiError) Unwrap() []error {
+	if err == nil {
+		return nil
+	}
+	return err.errors
+}
+
+// Code returns the first error code of the aggregated errors in depth-first order.
+// It returns CodeNil if none of the errors has error code.
+func (err *MultiError) Code() mincode.Code {
+	if err == nil {
+		return mincode.CodeNil
+	}
+	for _, e := range err.errors {
+		if code := Code(e); code != mincode.CodeNil {
+			return code
+		}
+	}
+	return mincode.CodeNil
+}
+
+// Cause returns the root cause error of the first aggregated error.
+func (err *MultiError) Cause() error {
+	if err == nil {
+		return nil
+	}
+	return causeOfMulti(err)
+}
+
+// Stack returns the error stack information of all aggregated errors as string.
+func (err *MultiError) Stack() string {
+	if err == nil {
+		return ""
+	}
+	return stackOf(err)
+}
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+// %v, %s   : Print all the error string;
+// %-v, %-s : Print current level error string;
+// %+s      : Print full stack error list;
+// %+v      : Print the error string and full stack error list.
+func (err *MultiError) Format(s fmt.State, verb rune) {
+	var output string
+
+	switch verb {
+	case 's', 'v':
+		switch {
+		case s.Flag('-'):
+			output = multiErrorText(len(err.errors))
+		case s.Flag('+'):
+			if verb == 's' {
+				output = err.Stack()
+			} else {
+				output = err.Error() + "\n" + err.Stack()
+			}
+		default:
+			output = err.Error()
+		}
+	}
+
+	_, _ = io.WriteString(s, output)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+// It marshals the structured form as Encode in JSONModeDetail mode.
+func (err MultiError) MarshalJSON() ([]byte, error) {
+	if errors.IsJSONModeDetail() {
+		return Encode(&err)
+	}
+	return json.Marshal(err.Error())
+}
+
+// multiErrorText returns the current level text of an error aggregating `n` errors.
+func multiErrorText(n int) string {
+	if n == 1 {
+		return "1 error occurred"
+	}
+	return fmt.Sprintf("%d errors occurred", n)
+}
@@ -9,17 +9,23 @@ import (
 	"bytes"
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/focela/min/errors/mincode"
 	"github.com/focela/min/internal/consts"
 	"github.com/focela/min/internal/errors"
 )
 
 // stackInfo manages stack info of a certain error.
 type stackInfo struct {
-	Index   int          // Index of the current error in the whole error stack.
+	Label   string       // Label of the current error in the whole error stack, eg: "2" or "2.1.1".
+	Depth   int          // Depth of the current error in the error tree, 0 for the top chain.
 	Message string       // Error information string.
 	Lines   []*stackLine // Slice contains all error stack lines of the current error stack in sequence.
+	// Hidden specifies whether the current error is printed with the next one, which is a wrapper without text
+	// of an error not of this package, eg: the wrapper of WithFields. Its Message is the fields if any.
+	Hidden bool
 }
 
 // stackLine manages each line info of the stack.
@@ -33,37 +39,80 @@ func (err *Error) Stack() string {
 	if err == nil {
 		return ""
 	}
-	var (
-		loop             = err
-		index            = 1
-		infos            []*stackInfo
-		isStackModeBrief = errors.IsStackModeBrief()
-	)
-	for loop != nil {
+	return stackOf(err)
+}
+
+// stackOf returns the error stack information of `err` as string.
+func stackOf(err error) string {
+	infos := appendStackInfos(nil, err, "", 0, errors.IsStackModeBrief())
+	filterLinesOfStackInfos(infos)
+	return formatStackInfos(infos)
+}
+
+// appendStackInfos appends the stack infos of `err` and its chaining errors to `infos`,
+// including the errors wrapped by the errors not of this package, eg: fmt.Errorf with %w.
+// The errors of a chain are labeled in sequence after `prefix`, and the errors wrapped by
+// an error wrapping multiple errors are labeled as its sub-levels, eg: "2.1.1" for the first
+// error of the chain of its first wrapped error.
+func appendStackInfos(infos []*stackInfo, err error, prefix string, depth int, isStackModeBrief bool) []*stackInfo {
+	for index := 1; err != nil; index++ {
 		info := &stackInfo{
-			Index:   index,
-			Message: fmt.Sprintf("%-v", loop),
+			Label: prefix + strconv.Itoa(index),
+			Depth: depth,
 		}
-		index++
 		infos = append(infos, info)
-		loopLinesOfStackInfo(loop.stack, info, isStackModeBrief)
-		if loop.error != nil {
-			if e, ok := loop.error.(*Error); ok {
-				loop = e
+		switch e := err.(type) {
+		case *Error:
+			if e.text == "" && (e.code == nil || e.code.Code() == mincode.CodeNil.Code()) && e.error != nil {
+				// The wrapper shares the label of the wrapped error, as they have the same error string.
+				info.Message, info.Hidden = formatFields(e.fields), true
+				index--
+			} else {
+				info.Message = fmt.Sprintf("%-v", e)
+				if fields := formatFields(e.fields); fields != "" {
+					info.Message += " " + fields
+				}
+			}
+			if e.frames != nil {
+				info.Lines = append(info.Lines, e.frames...)
 			} else {
-				infos = append(infos, &stackInfo{
-					Index:   index,
-					Message: loop.error.Error(),
-				})
-				index++
-				break
+				loopLinesOfStackInfo(e.stack, info, isStackModeBrief)
+			}
+			err = e.error
+
+		case MultiUnwrapper:
+			var wrapped []error
+			for _, v := range e.Unwrap() {
+				if v != nil {
+					wrapped = append(wrapped, v)
+				}
+			}
+			info.Message = multiErrorText(len(wrapped))
+			if m, ok := e.(*MultiError); ok {
+				if m.frames != nil {
+					info.Lines = append(info.Lines, m.frames...)
+				} else {
+					loopLinesOfStackInfo(m.stack, info, isStackModeBrief)
+				}
+			}
+			for i, v := range wrapped {
+				infos = appendStackInfos(
+					infos, v, info.Label+"."+strconv.Itoa(i+1)+".", depth+1, isStackModeBrief,
+				)
 			}
-		} else {
-			break
+			err = nil
+
+		case Unwrapper:
+			// The text of a wrapping error not of this package contains the text of its wrapped error.
+			info.Message = err.Error()
+			err = e.Unwrap()
+
+		default:
+			info.Message = err.Error()
+			err = nil
 		}
 	}
-	filterLinesOfStackInfos(infos)
-	return formatStackInfos(infos)
+	return infos
 }
 
 // filterLinesOfStackInfos removes repeated lines, which exist in subsequent stacks, from top errors.
@@ -89,27 +138,49 @@ func filterLinesOfStackInfos(infos []*stackInfo) {
 }
 
 // formatStackInfos formats and returns error stack information as string.
+// The errors wrapped by an error wrapping multiple errors are indented by their depth.
+// A hidden error is printed with the next error, whose message is followed by the fields of the hidden error
+// and whose stack lines are preceded by the stack lines of the hidden error.
 func formatStackInfos(infos []*stackInfo) string {
-	var buffer = bytes.NewBuffer(nil)
-	for i, info := range infos {
-		buffer.WriteString(fmt.Sprintf("%d. %s\n", i+1, info.Message))
-		if len(info.Lines) > 0 {
-			formatStackLines(buffer, info.Lines)
+	var (
+		buffer = bytes.NewBuffer(nil)
+		hidden *stackInfo
+	)
+	for _, info := range infos {
+		if info.Hidden {
+			hidden = info
+			continue
+		}
+		var (
+			indent  = strings.Repeat("   ", info.Depth)
+			message = info.Message
+			lines   = info.Lines
+		)
+		if hidden != nil {
+			if hidden.Message != "" {
+				message += " " + hidden.Message
+			}
+			lines = append(append([]*stackLine(nil), hidden.Lines...), lines...)
+			hidden = nil
+		}
+		buffer.WriteString(fmt.Sprintf("%s%s. %s\n", indent, info.Label, message))
+		if len(lines) > 0 {
+			formatStackLines(buffer, lines, indent)
 		}
 	}
 	return buffer.String()
 }
 
 // formatStackLines formats and returns error stack lines as string.
-func formatStackLines(buffer *bytes.Buffer, lines []*stackLine) {
+func formatStackLines(buffer *bytes.Buffer, lines []*stackLine, indent string) {
 	for i, line := range lines {
 		space := "  "
 		if i >= 9 {
 			space = " "
 		}
 		buffer.WriteString(fmt.Sprintf(
-			"   %d).%s%s\n        %s\n",
-			i+1, space, line.Function, line.FileLine,
+			"%s   %d).%s%s\n%s        %s\n",
+			indent, i+1, space, line.Function, indent, line.FileLine,
 		))
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package problem
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/focela/min/errors/minerror"
+)
+
+// Write writes the problem of `err` to `w` as the response of an HTTP handler,
+// with the content type ContentType and the status of the problem, eg:
+//
+//	if err := handle(r); err != nil {
+//		_ = problem.Write(w, err, problem.Option{Instance: r.URL.Path})
+//		return
+//	}
+//
+// It writes nothing if `err` is nil.
+func Write(w http.ResponseWriter, err error, option ...Option) error {
+	p := New(err, option...)
+	if p == nil {
+		return nil
+	}
+	data, e := json.Marshal(p)
+	if e != nil {
+		return minerror.Wrap(e, "marshal problem details document failed")
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if _, e = w.Write(data); e != nil {
+		return minerror.Wrap(e, "write problem details document failed")
+	}
+	return nil
+}
+
+// Read reads the Problem Details document from `reader`.
+func Read(reader io.Reader) (*Problem, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, minerror.Wrap(err, "read problem details document failed")
+	}
+	return Parse(data)
+}
+
+// ResponseError converts the HTTP response `resp` of a client to an error of package minerror,
+// it returns nil if the status of `resp` is not an error status, which is lower than 400.
+// The problem of `resp` is converted by Problem.Err with `option` if it has content type ContentType,
+// or else the error has the code mapped from the status.
+// The body of `resp` is read but not closed.
+func ResponseError(resp *http.Response, option ...Option) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == ContentType {
+		p, err := Read(resp.Body)
+		if err != nil {
+			return err
+		}
+		if p.Status == 0 {
+			p.Status = resp.StatusCode
+		}
+		return p.Err(option...)
+	}
+	return minerror.NewCodef(codeOfStatus(resp.StatusCode), "unexpected HTTP status %d", resp.StatusCode)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package problem_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/errors/minerror/problem"
+)
+
+func Test_Write_Read(t *testing.T) {
+	var (
+		recorder = httptest.NewRecorder()
+		err      = minerror.WithFields(minerror.Wrap(minerror.NewCode(mincode.CodeNotFound, "user 42 missing"), "handler"), minerror.Field("user", "42"))
+	)
+	if e := problem.Write(recorder, err, problem.Option{Instance: "/users/42"}); e != nil {
+		t.Fatal(e)
+	}
+	if recorder.Code != http.StatusNotFound || recorder.Header().Get("Content-Type") != problem.ContentType {
+		t.Fatalf("Write status = %d, content type = %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	p, e := problem.Read(recorder.Body)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if p.Status != http.StatusNotFound || p.Detail != "user 42 missing" || p.Instance != "/users/42" || p.Extensions["user"] != "42" {
+		t.Fatalf("Read = %+v", p)
+	}
+	if e = problem.Write(recorder, nil); e != nil {
+		t.Fatal(e)
+	}
+}
+
+func Test_Write_InvalidStatus(t *testing.T) {
+	var (
+		recorder = httptest.NewRecorder()
+		option   = problem.Option{Statuses: map[int]int{mincode.CodeNotFound.Code(): 40}}
+	)
+	if err := problem.Write(recorder, minerror.NewCode(mincode.CodeNotFound, "x"), option); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("Write status = %d, want the default status", recorder.Code)
+	}
+}
+
+func Test_ResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problem":
+			_ = problem.Write(w, minerror.NewCode(mincode.New(1001, "Out Of Stock", nil), "item 7 sold out"),
+				problem.Option{Statuses: map[int]int{1001: http.StatusConflict}})
+		case "/text":
+			http.Error(w, "gone away", http.StatusBadGateway)
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	defer server.Close()
+
+	for _, c := range []struct {
+		path string
+		code int
+		text string
+	}{
+		{"/problem", 1001, "item 7 sold out"},
+		{"/text", mincode.CodeInternalError.Code(), "unexpected HTTP status 502"},
+		{"/ok", mincode.CodeNil.Code(), ""},
+	} {
+		resp, err := http.Get(server.URL + c.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = problem.ResponseError(resp)
+		_ = resp.Body.Close()
+		if c.text == "" {
+			if err != nil {
+				t.Errorf("%s: ResponseError = %v, want nil", c.path, err)
+			}
+			continue
+		}
+		if err == nil || minerror.Code(err).Code() != c.code || !strings.Contains(err.Error(), c.text) {
+			t.Errorf("%s: ResponseError = %v, code %v", c.path, err, minerror.Code(err))
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+// Package problem provides conversion between the errors of package minerror and
+// the Problem Details documents of RFC 9457, which are used as HTTP error responses.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+)
+
+const (
+	// ContentType is the media type of the Problem Details documents in JSON.
+	ContentType = "application/problem+json"
+	// DefaultType is the type of the problems which have no specific type, see RFC 9457 section 4.2.1.
+	DefaultType = "about:blank"
+	// CodeMember is the extension member recording the error code number of the problem.
+	CodeMember = "code"
+	// DataMember is the extension member recording the code detail.
+	DataMember = "data"
+)
+
+// Problem is a Problem Details document of RFC 9457.
+type Problem struct {
+	Type       string                 // URI reference identifying the problem type, DefaultType if empty.
+	Title      string                 // Short summary of the problem type.
+	Status     int                    // HTTP status code of the problem.
+	Detail     string                 // Explanation specific to this occurrence of the problem.
+	Instance   string                 // URI reference identifying this occurrence of the problem.
+	Extensions map[string]interface{} // Extension members, which are marshaled as members of the document.
+}
+
+// Option is the option for the conversion between errors and problems.
+type Option struct {
+	// Types is the type URIs of the problems by error code numbers, DefaultType for the codes not in it.
+	// If several codes have the same type URI, the smallest code number is used for the type by Problem.Err.
+	Types map[int]string
+	// Statuses is the HTTP statuses by error code numbers, which take precedence over the default statuses.
+	// The statuses out of range 100-999, which are invalid for http.ResponseWriter, are ignored.
+	Statuses map[int]int
+	// Instance is the instance of the problem, eg: the request path.
+	Instance string
+	// ServerDetail specifies whether to expose the detail of the problems of server error statuses (5xx),
+	// which is omitted in default, as it may contain internal information.
+	ServerDetail bool
+}
+
+// defaultStatuses is the default HTTP statuses of the common error codes.
+var defaultStatuses = map[int]int{
+	mincode.CodeOK.Code():                        http.StatusOK,
+	mincode.CodeInternalError.Code():             http.StatusInternalServerError,
+	mincode.CodeValidationFailed.Code():          http.StatusBadRequest,
+	mincode.CodeDbOperationError.Code():          http.StatusInternalServerError,
+	mincode.CodeInvalidParameter.Code():          http.StatusBadRequest,
+	mincode.CodeMissingParameter.Code():          http.StatusBadRequest,
+	mincode.CodeInvalidOperation.Code():          http.StatusBadRequest,
+	mincode.CodeInvalidConfiguration.Code():      http.StatusInternalServerError,
+	mincode.CodeMissingConfiguration.Code():      http.StatusInternalServerError,
+	mincode.CodeNotImplemented.Code():            http.StatusNotImplemented,
+	mincode.CodeNotSupported.Code():              http.StatusNotImplemented,
+	mincode.CodeOperationFailed.Code():           http.StatusInternalServerError,
+	mincode.CodeNotAuthorized.Code():             http.StatusUnauthorized,
+	mincode.CodeSecurityReason.Code():            http.StatusForbidden,
+	mincode.CodeServerBusy.Code():                http.StatusServiceUnavailable,
+	mincode.CodeUnknown.Code():                   http.StatusInternalServerError,
+	mincode.CodeNotFound.Code():                  http.StatusNotFound,
+	mincode.CodeInvalidRequest.Code():            http.StatusBadRequest,
+	mincode.CodeNecessaryPackageNotImport.Code(): http.StatusInternalServerError,
+	mincode.CodeInternalPanic.Code():             http.StatusInternalServerError,
+	mincode.CodeBusinessValidationFailed.Code():  http.StatusUnprocessableEntity,
+}
+
+// defaultCodes is the default error codes of the HTTP statuses, which is used for the problems without code member.
+var defaultCodes = map[int]mincode.Code{
+	http.StatusBadRequest:          mincode.CodeInvalidRequest,
+	http.StatusUnauthorized:        mincode.CodeNotAuthorized,
+	http.StatusForbidden:           mincode.CodeSecurityReason,
+	http.StatusNotFound:            mincode.CodeNotFound,
+	http.StatusUnprocessableEntity: mincode.CodeBusinessValidationFailed,
+	http.StatusInternalServerError: mincode.CodeInternalError,
+	http.StatusNotImplemented:      mincode.CodeNotImplemented,
+	http.StatusServiceUnavailable:  mincode.CodeServerBusy,
+}
+
+// New creates and returns the problem of `err`.
+// The title is the message of the error code, the status is mapped from the error code,
+// which is 500 for the errors without code, and the detail is the message of the error in the chain
+// of `err` that carries the error code, without its wrapped errors, or else the message of `err`.
+// The detail is omitted for server error statuses (5xx) unless the option ServerDetail is true,
+// and it is also omitted if it is the same as the title.
+// The error code number is recorded as extension member CodeMember, the code detail is recorded
+// as extension member DataMember, and the fields of `err` are recorded as extension members,
+// except the fields named CodeMember, DataMember or the members defined by RFC 9457.
+// It returns nil if `err` is nil.
+func New(err error, option ...Option) *Problem {
+	if err == nil {
+		return nil
+	}
+	var (
+		opt  = getOption(option)
+		code = minerror.Code(err)
+		p    = &Problem{
+			Type:     DefaultType,
+			Title:    code.Message(),
+			Status:   http.StatusInternalServerError,
+			Instance: opt.Instance,
+		}
+	)
+	if code.Code() != mincode.CodeNil.Code() {
+		if status, ok := opt.Statuses[code.Code()]; ok && isValidStatus(status) {
+			p.Status = status
+		} else if status, ok = defaultStatuses[code.Code()]; ok {
+			p.Status = status
+		}
+		if t, ok := opt.Types[code.Code()]; ok {
+			p.Type = t
+		}
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Status < http.StatusInternalServerError || opt.ServerDetail {
+		if detail := messageOf(originOf(err, code)); detail != p.Title {
+			p.Detail = detail
+		}
+	}
+	p.Extensions = extensionsOf(err)
+	if code.Code() != mincode.CodeNil.Code() {
+		p.Extensions[CodeMember] = code.Code()
+		if detail := code.Detail(); detail != nil {
+			p.Extensions[DataMember] = detail
+		}
+	}
+	if len(p.Extensions) == 0 {
+		p.Extensions = nil
+	}
+	return p
+}
+
+// Parse parses the Problem Details document `data`.
+func Parse(data []byte) (*Problem, error) {
+	p := &Problem{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, minerror.WrapCode(mincode.CodeInvalidParameter, err, "invalid problem details document")
+	}
+	return p, nil
+}
+
+// Err converts the problem to an error of package minerror with the detail as its text,
+// which is the reverse of New.
+// The error code number is from extension member CodeMember, or the type URI mapped
+// by `option`, or else the status of the problem. The message of the error code is
+// the title, and the detail of the error code is extension member DataMember, which is
+// minerror.JSONDetail if it is a JSON object or array, so that the error codes stay comparable.
+// The other extension members are attached to the error as its fields, whose numbers are float64
+// as they are unmarshaled from JSON. The error code without detail equals the common error code
+// of the same number if the title is the message of the common error code.
+// It returns nil if the problem is nil.
+func (p *Problem) Err(option ...Option) error {
+	if p == nil {
+		return nil
+	}
+	var (
+		opt    = getOption(option)
+		number int
+		found  bool
+		detail interface{}
+		fields []minerror.KeyValue
+	)
+	for key, value := range p.Extensions {
+		switch key {
+		case CodeMember:
+			if f, ok := value.(float64); ok && f == float64(int(f)) {
+				number, found = int(f), true
+				continue
+			}
+			if n, ok := value.(int); ok {
+				number, found = n, true
+				continue
+			}
+		case DataMember:
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				detail = minerror.NewJSONDetail(value)
+			default:
+				detail = value
+			}
+			continue
+		}
+		fields = append(fields, minerror.Field(key, value))
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Key < fields[j].Key
+	})
+	if !found && p.Type != "" && p.Type != DefaultType {
+		for n, t := range opt.Types {
+			if t == p.Type && (!found || n < number) {
+				number, found = n, true
+			}
+		}
+	}
+	if !found {
+		number = codeOfStatus(p.Status).Code()
+	}
+	err := minerror.NewCodeWithSkip(mincode.New(number, p.Title, detail), 1, p.Detail)
+	if len(fields) > 0 {
+		return minerror.WithFields(err, fields...)
+	}
+	return err
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+// The extension members are marshaled as members of the document,
+// which do not override the members defined by RFC 9457.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = DefaultType
+	}
+	members["title"] = p.Title
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+// The members defined by RFC 9457 of unexpected types are ignored as the RFC requires,
+// and the other members are unmarshaled as extension members.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	result := Problem{Type: DefaultType}
+	for key, raw := range members {
+		// The errors of the members defined by RFC 9457 are ignored, which keep their zero values.
+		switch key {
+		case "type":
+			_ = json.Unmarshal(raw, &result.Type)
+		case "title":
+			_ = json.Unmarshal(raw, &result.Title)
+		case "status":
+			_ = json.Unmarshal(raw, &result.Status)
+		case "detail":
+			_ = json.Unmarshal(raw, &result.Detail)
+		case "instance":
+			_ = json.Unmarshal(raw, &result.Instance)
+		default:
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			if result.Extensions == nil {
+				result.Extensions = make(map[string]interface{})
+			}
+			result.Extensions[key] = value
+		}
+	}
+	*p = result
+	return nil
+}
+
+// getOption returns the first option of `option`, or an empty option.
+func getOption(option []Option) Option {
+	if len(option) > 0 {
+		return option[0]
+	}
+	return Option{}
+}
+
+// isValidStatus checks whether `status` is a valid HTTP status for http.ResponseWriter.
+func isValidStatus(status int) bool {
+	return status >= 100 && status <= 999
+}
+
+// originOf returns the error in the chain of `err` that carries `code`, which is the error having
+// `code` while its wrapped error does not, or `err` itself if there is none.
+func originOf(err error, code mincode.Code) error {
+	if code.Code() == mincode.CodeNil.Code() {
+		return err
+	}
+	origin := minerror.Find(err, func(err error) bool {
+		if _, ok := err.(minerror.MultiUnwrapper); ok {
+			return false
+		}
+		e, ok := err.(minerror.CodeRetriever)
+		return ok && isSameCode(e.Code(), code) && !isSameCode(minerror.Code(minerror.Unwrap(err)), code)
+	})
+	if origin == nil {
+		return err
+	}
+	return origin
+}
+
+// isSameCode checks whether `a` and `b` have the same number and message,
+// which does not compare the details as they may be uncomparable.
+func isSameCode(a, b mincode.Code) bool {
+	return a.Code() == b.Code() && a.Message() == b.Message()
+}
+
+// messageOf returns the error message of `err` without its wrapped errors, the errors without text,
+// such as the errors wrapping foreign errors with fields, are skipped to their wrapped errors.
+func messageOf(err error) string {
+	for err != nil {
+		e, ok := err.(*minerror.Error)
+		if !ok {
+			return err.Error()
+		}
+		if message := e.BaseError().Error(); message != "" || e.Unwrap() == nil {
+			return message
+		}
+		err = e.Unwrap()
+	}
+	return ""
+}
+
+// codeOfStatus returns the default error code of HTTP `status`.
+func codeOfStatus(status int) mincode.Code {
+	if code, ok := defaultCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return mincode.CodeInternalError
+	}
+	return mincode.CodeUnknown
+}
+
+// extensionsOf returns the extension members of the fields of `err`,
+// except the fields named as the members defined by RFC 9457, CodeMember or DataMember.
+func extensionsOf(err error) map[string]interface{} {
+	extensions := make(map[string]interface{})
+	for key, value := range minerror.Fields(err) {
+		switch key {
+		case "type", "title", "status", "detail", "instance", CodeMember, DataMember:
+		default:
+			extensions[key] = value
+		}
+	}
+	return extensions
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2024 Focela Technologies. All rights reserved.
+//
+// Use of this source code is governed by an MIT-style license
+// that can be found in the LICENSE file.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/focela/min/errors/mincode"
+	"github.com/focela/min/errors/minerror"
+	"github.com/focela/min/errors/minerror/problem"
+)
+
+func Test_New(t *testing.T) {
+	coded := minerror.NewCode(mincode.CodeNotFound, "user 42 missing")
+	for _, c := range []struct {
+		name   string
+		err    error
+		status int
+		title  string
+		detail string
+	}{
+		{"code", coded, http.StatusNotFound, "Not Found", "user 42 missing"},
+		{"wrapped", minerror.Wrap(coded, "handler"), http.StatusNotFound, "Not Found", "user 42 missing"},
+		{"foreign wrapper", minerror.Wrap(fmt.Errorf("load: %w", coded), "handler"), http.StatusNotFound, "Not Found", "user 42 missing"},
+		{"fields", minerror.WithFields(coded, minerror.Field("user", 42)), http.StatusNotFound, "Not Found", "user 42 missing"},
+		{"foreign fields", minerror.WithFields(fmt.Errorf("bad input"), minerror.Field("k", 1)), http.StatusInternalServerError, "Internal Server Error", ""},
+		{"wrap code", minerror.WrapCode(mincode.CodeInvalidParameter, errors.New("eof"), "bad body"), http.StatusBadRequest, "Invalid Parameter", "bad body"},
+		{"code message", minerror.NewCode(mincode.CodeNotFound), http.StatusNotFound, "Not Found", ""},
+		{"joined", minerror.Join(errors.New("a"), minerror.Wrap(coded, "b")), http.StatusNotFound, "Not Found", "user 42 missing"},
+	} {
+		p := problem.New(c.err)
+		if p.Status != c.status || p.Title != c.title || p.Detail != c.detail || p.Type != problem.DefaultType {
+			t.Errorf("%s: New = %+v", c.name, p)
+		}
+	}
+	if problem.New(nil) != nil {
+		t.Fatal("New(nil) is not nil")
+	}
+}
+
+func Test_New_ForeignFieldsDetail(t *testing.T) {
+	p := problem.New(minerror.WithFields(fmt.Errorf("bad input"), minerror.Field("k", 1)), problem.Option{ServerDetail: true})
+	if p.Detail != "bad input" || !reflect.DeepEqual(p.Extensions, map[string]interface{}{"k": 1}) {
+		t.Fatalf("New = %+v", p)
+	}
+}
+
+func Test_New_ServerDetail(t *testing.T) {
+	err := minerror.NewCode(mincode.CodeDbOperationError, "dial tcp 10.0.0.1:5432 refused")
+	if p := problem.New(err); p.Status != http.StatusInternalServerError || p.Detail != "" {
+		t.Fatalf("detail of 5xx is exposed: %+v", p)
+	}
+	if p := problem.New(err, problem.Option{ServerDetail: true}); p.Detail != "dial tcp 10.0.0.1:5432 refused" {
+		t.Fatalf("detail of 5xx with ServerDetail = %q", p.Detail)
+	}
+	if p := problem.New(errors.New("panic")); p.Detail != "" || p.Extensions != nil {
+		t.Fatalf("New of foreign error = %+v", p)
+	}
+}
+
+func Test_Option(t *testing.T) {
+	var (
+		outOfStock = mincode.New(1001, "Out Of Stock", nil)
+		noPayment  = mincode.New(1002, "No Payment", nil)
+		option     = problem.Option{
+			Types: map[int]string{
+				1001: "https://example.com/probs/order",
+				1002: "https://example.com/probs/order",
+			},
+			Statuses: map[int]int{
+				1001:                          http.StatusConflict,
+				1002:                          1000,
+				mincode.CodeNotFound.Code():   http.StatusGone,
+				mincode.CodeServerBusy.Code(): 0,
+			},
+			Instance: "/orders/1",
+		}
+	)
+	for _, c := range []struct {
+		err    error
+		status int
+		typ    string
+	}{
+		{minerror.NewCode(outOfStock, "x"), http.StatusConflict, "https://example.com/probs/order"},
+		{minerror.NewCode(noPayment, "x"), http.StatusInternalServerError, "https://example.com/probs/order"},
+		{minerror.NewCode(mincode.CodeNotFound, "x"), http.StatusGone, problem.DefaultType},
+		{minerror.NewCode(mincode.CodeServerBusy, "x"), http.StatusServiceUnavailable, problem.DefaultType},
+	} {
+		p := problem.New(c.err, option)
+		if p.Status != c.status || p.Type != c.typ || p.Instance != "/orders/1" {
+			t.Errorf("New(%v) = %+v", minerror.Code(c.err), p)
+		}
+	}
+	// The smallest code number is used for the shared type URI.
+	for i := 0; i < 10; i++ {
+		p := &problem.Problem{Type: "https://example.com/probs/order", Title: "Order", Status: http.StatusConflict}
+		if code := minerror.Code(p.Err(option)); code.Code() != 1001 {
+			t.Fatalf("Err code = %d, want 1001", code.Code())
+		}
+	}
+}
+
+func Test_Err(t *testing.T) {
+	for _, c := range []struct {
+		problem *problem.Problem
+		code    int
+	}{
+		{&problem.Problem{Status: http.StatusNotFound, Extensions: map[string]interface{}{"code": float64(1001)}}, 1001},
+		{&problem.Problem{Status: http.StatusNotFound}, mincode.CodeNotFound.Code()},
+		{&problem.Problem{Status: http.StatusBadGateway}, mincode.CodeInternalError.Code()},
+		{&problem.Problem{Status: http.StatusTeapot}, mincode.CodeUnknown.Code()},
+	} {
+		if code := minerror.Code(c.problem.Err()); code.Code() != c.code {
+			t.Errorf("Err of %+v = %d, want %d", c.problem, code.Code(), c.code)
+		}
+	}
+	var p *problem.Problem
+	if p.Err() != nil {
+		t.Fatal("Err of nil problem is not nil")
+	}
+	err := (&problem.Problem{Title: "Not Found", Status: http.StatusNotFound, Extensions: map[string]interface{}{"code": float64(mincode.CodeNotFound.Code())}}).Err()
+	if minerror.Code(err) != mincode.CodeNotFound {
+		t.Fatalf("Err code = %v, want the common code", minerror.Code(err))
+	}
+}
+
+func Test_New_Err(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		err    error
+		detail interface{}
+	}{
+		{"no detail", minerror.NewCode(mincode.CodeNotFound, "user 42 missing"), nil},
+		{"string detail", minerror.NewCode(mincode.New(1001, "Quota", "daily"), "over quota"), "daily"},
+		{"object detail", minerror.NewCode(mincode.New(1001, "Quota", map[string]interface{}{"code": 7, "limit": 10}), "over quota"), minerror.JSONDetail(`{"code":7,"limit":10}`)},
+		{"array detail", minerror.NewCode(mincode.New(1001, "Quota", []int{1, 2}), "over quota"), minerror.JSONDetail(`[1,2]`)},
+		{"fields", minerror.WithFields(minerror.NewCode(mincode.CodeInvalidParameter, "bad"), minerror.Field("name", "x"), minerror.Field("count", 2)), nil},
+	} {
+		data, err := json.Marshal(problem.New(c.err, problem.Option{ServerDetail: true}))
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", c.name, err)
+		}
+		p, err := problem.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", c.name, err)
+		}
+		var (
+			result = p.Err()
+			code   = minerror.Code(result)
+			want   = minerror.Code(c.err)
+		)
+		if result.Error() != c.err.Error() || code.Code() != want.Code() || code.Message() != want.Message() {
+			t.Errorf("%s: Err = %q %v, want %q %v", c.name, result, code, c.err, want)
+		}
+		if code.Detail() != c.detail {
+			t.Errorf("%s: Detail = %#v, want %#v", c.name, code.Detail(), c.detail)
+		}
+		if got, want := fmt.Sprint(minerror.Fields(result)), fmt.Sprint(minerror.Fields(c.err)); got != want {
+			t.Errorf("%s: Fields = %s, want %s", c.name, got, want)
+		}
+	}
+}
+
+func Test_Problem_JSON(t *testing.T) {
+	p := &problem.Problem{
+		Type:       "https://example.com/probs/out-of-credit",
+		Title:      "You do not have enough credit.",
+		Status:     http.StatusForbidden,
+		Detail:     "Your current balance is 30, but that costs 50.",
+		Instance:   "/account/12345/msgs/abc",
+		Extensions: map[string]interface{}{"balance": float64(30), "title": "ignored"},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"balance":30,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc",` +
+		`"status":403,"title":"You do not have enough credit.","type":"https://example.com/probs/out-of-credit"}`
+	if string(data) != want {
+		t.Fatalf("Marshal = %s", data)
+	}
+	parsed, err := problem.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Extensions = map[string]interface{}{"balance": float64(30)}
+	if !reflect.DeepEqual(parsed, p) {
+		t.Fatalf("Parse = %+v, want %+v", parsed, p)
+	}
+	// The members of unexpected types are ignored.
+	if parsed, err = problem.Parse([]byte(`{"status":"404","title":1}`)); err != nil || parsed.Status != 0 || parsed.Title != "" || parsed.Type != problem.DefaultType {
+		t.Fatalf("Parse of invalid members = %+v, %v", parsed, err)
+	}
+	if _, err = problem.Parse([]byte(`[]`)); minerror.Code(err) != mincode.CodeInvalidParameter {
+		t.Fatalf("Parse of invalid document = %v", err)
+	}
+}
@@ -20,6 +20,9 @@ const (
 
 	// commandEnvKeyForStackMode is the command environment name for switching error stack modes (brief or detail).
 	commandEnvKeyForStackMode = "min.error.stack.mode"
+
+	// commandEnvKeyForJSONMode is the command environment name for switching error JSON modes (text or detail).
+	commandEnvKeyForJSONMode = "min.error.json.mode"
 )
 
 const (
@@ -30,10 +33,25 @@ const (
 	StackModeDetail StackMode = "detail"
 )
 
+// JSONMode is the mode that marshaling errors to JSON in JSONModeText or JSONModeDetail mode.
+type JSONMode string
+
+const (
+	// JSONModeText specifies all errors marshaling to their error strings.
+	JSONModeText JSONMode = "text"
+
+	// JSONModeDetail specifies all errors marshaling to structured objects including codes, stacks and wrapped errors.
+	JSONModeDetail JSONMode = "detail"
+)
+
 var (
 	// stackModeConfigured is the configured error stack mode variable.
 	// It is brief stack mode in default.
 	stackModeConfigured = StackModeBrief
+
+	// jsonModeConfigured is the configured error JSON mode variable.
+	// It is text JSON mode in default.
+	jsonModeConfigured = JSONModeText
 )
 
 func init() {
@@ -49,9 +67,30 @@ func init() {
 			stackModeConfigured = StackMode(stackModeSetting)
 		}
 	}
+
+	// Set the JSON mode based on command line arguments or environment variables.
+	if jsonModeSetting := command.GetOptionWithEnv(commandEnvKeyForJSONMode); jsonModeSetting != "" {
+		switch JSONMode(jsonModeSetting) {
+		case JSONModeText, JSONModeDetail:
+			jsonModeConfigured = JSONMode(jsonModeSetting)
+		}
+	}
 }
 
 // IsStackModeBrief checks if the current error stack mode is set to brief mode.
 func IsStackModeBrief() bool {
 	return stackModeConfigured == StackModeBrief
 }
+
+// IsJSONModeDetail checks if the current error JSON mode is set to detail mode.
+func IsJSONModeDetail() bool {
+	return jsonModeConfigured == JSONModeDetail
+}
+
+// SetJSONMode sets the error JSON mode, which is ignored if `mode` is not a valid mode.
+func SetJSONMode(mode JSONMode) {
+	switch mode {
+	case JSONModeText, JSONModeDetail:
+		jsonModeConfigured = mode
+	}
+}